@@ -0,0 +1,232 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/choral-io/gommerce-server-core/config"
+	"github.com/choral-io/gommerce-server-core/logging"
+	"github.com/choral-io/gommerce-server-core/secure"
+)
+
+const instrumentationName = "github.com/choral-io/gommerce-server-core/audit"
+
+// Auditor buffers audit records and writes them by a Sink in batches from a background goroutine.
+type Auditor struct {
+	sink           Sink
+	logger         logging.Logger
+	records        chan *Record
+	batchSize      int
+	flushInterval  time.Duration
+	block          bool
+	blockTimeout   time.Duration
+	maxRequestSize int
+	redactFields   []string
+	dropped        metric.Int64Counter
+	failed         metric.Int64Counter
+	mu             sync.RWMutex
+	closed         bool
+	done           chan struct{}
+}
+
+// NewAuditor returns a new Auditor with the given config, and starts writing records by the given sink.
+// The audit.records.dropped and audit.records.failed counters are registered with the given MeterProvider.
+func NewAuditor(cfg config.AuditConfig, sink Sink, logger logging.Logger, mp metric.MeterProvider) (*Auditor, error) {
+	a := &Auditor{
+		sink:           sink,
+		logger:         logging.Named(logger, "audit"),
+		records:        make(chan *Record, max(cfg.GetBufferSize(), 1)),
+		batchSize:      max(cfg.GetBatchSize(), 1),
+		flushInterval:  cfg.GetFlushInterval(),
+		blockTimeout:   cfg.GetBlockTimeout(),
+		maxRequestSize: cfg.GetMaxRequestSize(),
+		redactFields:   cfg.GetRedactFields(),
+		done:           make(chan struct{}),
+	}
+	switch cfg.GetOverflow() {
+	case "drop":
+	case "block":
+		a.block = true
+	default:
+		return nil, fmt.Errorf("unknown audit overflow: %s", cfg.GetOverflow())
+	}
+	if a.flushInterval <= 0 {
+		return nil, errors.New("audit flush interval must be positive")
+	}
+	meter := mp.Meter(instrumentationName)
+	var err error
+	if a.dropped, err = meter.Int64Counter("audit.records.dropped",
+		metric.WithUnit("{record}"),
+		metric.WithDescription("Number of audit records dropped because the buffer is full or the auditor is closed."),
+	); err != nil {
+		return nil, err
+	}
+	if a.failed, err = meter.Int64Counter("audit.records.failed",
+		metric.WithUnit("{record}"),
+		metric.WithDescription("Number of audit records failed to be written by the sink."),
+	); err != nil {
+		return nil, err
+	}
+	go a.run()
+	return a, nil
+}
+
+// Record buffers the given record. If the buffer is full, the record is dropped,
+// or it waits up to the block timeout for the buffer if the overflow is block.
+func (a *Auditor) Record(ctx context.Context, r *Record) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		a.dropped.Add(ctx, 1)
+		return
+	}
+	select {
+	case a.records <- r:
+		return
+	default:
+	}
+	if a.block {
+		timer := time.NewTimer(a.blockTimeout)
+		defer timer.Stop()
+		select {
+		case a.records <- r:
+			return
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+	a.dropped.Add(ctx, 1)
+}
+
+// Close stops buffering records, and waits until the buffered records are written or ctx is done.
+func (a *Auditor) Close(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.records)
+	}
+	a.mu.Unlock()
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *Auditor) run() {
+	defer close(a.done)
+	ticker := time.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+	batch := make([]*Record, 0, a.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx := context.Background()
+		if err := a.sink.Write(ctx, batch); err != nil {
+			a.failed.Add(ctx, int64(len(batch)))
+			a.logger.Error(ctx, "failed to write audit records", "error", err, "count", len(batch))
+		}
+		batch = make([]*Record, 0, a.batchSize)
+	}
+	for {
+		select {
+		case r, ok := <-a.records:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, r)
+			if len(batch) >= a.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that records audit records of calls.
+// It should be chained before the secure interceptor, so denied calls are recorded with the resolved identity.
+func (a *Auditor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, captured := secure.ContextWithIdentityCapture(ctx)
+		resp, err := handler(ctx, req)
+		a.Record(ctx, a.newRecord(ctx, start, info.FullMethod, req, captured(), err))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor that records audit records of calls.
+// The request summary of streaming calls is the first message received from the client.
+// It should be chained before the secure interceptor, so denied calls are recorded with the resolved identity.
+func (a *Auditor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, captured := secure.ContextWithIdentityCapture(ss.Context())
+		ws := &auditServerStream{ServerStream: ss, ctx: ctx}
+		err := handler(srv, ws)
+		a.Record(ctx, a.newRecord(ctx, start, info.FullMethod, ws.first, captured(), err))
+		return err
+	}
+}
+
+func (a *Auditor) newRecord(ctx context.Context, start time.Time, method string, req any, user *secure.Identity, err error) *Record {
+	r := &Record{
+		Time:    start,
+		Method:  method,
+		Code:    status.Code(err).String(),
+		Latency: time.Since(start),
+	}
+	if user == nil {
+		user = secure.IdentityFromContext(ctx)
+	}
+	if user != nil {
+		r.Schema = user.Schema()
+		if token := user.Token(); token != nil {
+			r.Realm = token.Realm()
+			r.Client = token.Client()
+			r.Subject = token.Subject()
+		}
+	}
+	if msg, ok := req.(proto.Message); ok {
+		r.Request = logging.RenderProtoMessage(msg, a.maxRequestSize, a.redactFields...)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		r.TraceID = sc.TraceID().String()
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.PeerAddr = p.Addr.String()
+	}
+	return r
+}
+
+type auditServerStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	first any
+}
+
+func (s *auditServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *auditServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.first == nil {
+		s.first = m
+	}
+	return err
+}
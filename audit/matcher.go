@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// MethodOptionMatcher returns a selector.Matcher that matches methods with the given bool extension set to true
+// in their method options, or in the options of their service.
+// Methods are looked up in protoregistry.GlobalFiles, and the results are cached.
+func MethodOptionMatcher(xt protoreflect.ExtensionType) selector.Matcher {
+	var cache sync.Map // map[string]bool
+	return selector.MatchFunc(func(_ context.Context, callMeta interceptors.CallMeta) bool {
+		method := callMeta.FullMethod()
+		if matched, ok := cache.Load(method); ok {
+			return matched.(bool)
+		}
+		matched := hasMethodOption(method, xt)
+		cache.Store(method, matched)
+		return matched
+	})
+}
+
+func hasMethodOption(fullMethod string, xt protoreflect.ExtensionType) bool {
+	// full method is in the form of "/package.Service/Method"
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(fullMethod, "/"), "/", "."))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return false
+	}
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return false
+	}
+	if isOptionSet(md.Options(), xt) {
+		return true
+	}
+	if sd, ok := md.Parent().(protoreflect.ServiceDescriptor); ok {
+		return isOptionSet(sd.Options(), xt)
+	}
+	return false
+}
+
+func isOptionSet(opts proto.Message, xt protoreflect.ExtensionType) bool {
+	if opts == nil || !proto.HasExtension(opts, xt) {
+		return false
+	}
+	v, ok := proto.GetExtension(opts, xt).(bool)
+	return ok && v
+}
@@ -0,0 +1,27 @@
+// Package audit records security-relevant grpc calls for compliance,
+// including who made the call, the method, a request summary, the outcome and the latency.
+package audit
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Record is an audit record of a grpc call.
+type Record struct {
+	bun.BaseModel `bun:"table:audit_records" json:"-"`
+
+	ID       int64         `bun:"id,pk,autoincrement" json:"-"`
+	Time     time.Time     `bun:"time,notnull" json:"time"`
+	Method   string        `bun:"method,notnull" json:"method"`
+	Schema   string        `bun:"schema" json:"schema,omitempty"`
+	Realm    string        `bun:"realm" json:"realm,omitempty"`
+	Client   string        `bun:"client" json:"client,omitempty"`
+	Subject  string        `bun:"subject" json:"subject,omitempty"`
+	Request  string        `bun:"request" json:"request,omitempty"` // request summary rendered as json, sensitive fields are redacted
+	Code     string        `bun:"code,notnull" json:"code"`         // grpc code of the outcome
+	Latency  time.Duration `bun:"latency,notnull" json:"latency"`
+	TraceID  string        `bun:"trace_id" json:"trace_id,omitempty"`
+	PeerAddr string        `bun:"peer_addr" json:"peer_addr,omitempty"`
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/uptrace/bun"
+
+	"github.com/choral-io/gommerce-server-core/config"
+	"github.com/choral-io/gommerce-server-core/logging"
+)
+
+// Sink writes audit records.
+type Sink interface {
+	// Write writes the given records, it is called by a single goroutine of Auditor.
+	Write(ctx context.Context, records []*Record) error
+}
+
+// NewSink returns a new Sink with the given config.
+// There are three types of sinks: bun, nats, and logger.
+// bun.IDB is required if the type of sink is bun, and nats.Conn is required if the type of sink is nats.
+func NewSink(cfg config.AuditConfig, db bun.IDB, nc *nats.Conn, logger logging.Logger) (Sink, error) {
+	switch cfg.GetSink() {
+	case "bun":
+		if db == nil {
+			return nil, errors.New("bun db is required for bun audit sink")
+		}
+		return NewBunSink(db, cfg.GetTable()), nil
+	case "nats":
+		if nc == nil {
+			return nil, errors.New("nats connection is required for nats audit sink")
+		}
+		return NewNATSSink(nc, cfg.GetSubject()), nil
+	case "logger":
+		return NewLoggerSink(logger), nil
+	}
+	return nil, fmt.Errorf("unknown audit sink: %s", cfg.GetSink())
+}
+
+// BunSink writes audit records into a database table.
+type BunSink struct {
+	db    bun.IDB
+	table string
+}
+
+// NewBunSink returns a new BunSink writing into the given table.
+func NewBunSink(db bun.IDB, table string) *BunSink {
+	return &BunSink{db: db, table: table}
+}
+
+// CreateTable creates the table of audit records if it does not exist.
+func (s *BunSink) CreateTable(ctx context.Context) error {
+	_, err := s.db.NewCreateTable().Model((*Record)(nil)).ModelTableExpr(s.table).IfNotExists().Exec(ctx)
+	return err
+}
+
+func (s *BunSink) Write(ctx context.Context, records []*Record) error {
+	_, err := s.db.NewInsert().Model(&records).ModelTableExpr(s.table).Exec(ctx)
+	return err
+}
+
+// NATSSink publishes audit records as json messages to a nats subject.
+type NATSSink struct {
+	nc      *nats.Conn
+	subject string
+}
+
+// NewNATSSink returns a new NATSSink publishing to the given subject.
+func NewNATSSink(nc *nats.Conn, subject string) *NATSSink {
+	return &NATSSink{nc: nc, subject: subject}
+}
+
+func (s *NATSSink) Write(ctx context.Context, records []*Record) error {
+	var errs []error
+	for _, r := range records {
+		data, err := json.Marshal(r)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.nc.Publish(s.subject, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoggerSink logs audit records at level Info, with a logger named "audit".
+type LoggerSink struct {
+	logger logging.Logger
+}
+
+// NewLoggerSink returns a new LoggerSink logging to the given logger.
+func NewLoggerSink(logger logging.Logger) *LoggerSink {
+	return &LoggerSink{logger: logging.Named(logger, "audit")}
+}
+
+func (s *LoggerSink) Write(ctx context.Context, records []*Record) error {
+	for _, r := range records {
+		s.logger.Info(ctx, "audit",
+			"time", r.Time,
+			"method", r.Method,
+			"schema", r.Schema,
+			"realm", r.Realm,
+			"client", r.Client,
+			"subject", r.Subject,
+			"request", r.Request,
+			"code", r.Code,
+			"latency", r.Latency,
+			"trace_id", r.TraceID,
+			"peer_addr", r.PeerAddr,
+		)
+	}
+	return nil
+}
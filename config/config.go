@@ -23,6 +23,7 @@ type ExtractSectionsResult struct {
 	MetricConfig      MetricConfig
 	SecureConfig      SecureConfig
 	SecureTokenConfig SecureTokenConfig
+	AuditConfig       AuditConfig
 }
 
 // ExtractSections extracts sections from RootConfig.
@@ -41,6 +42,7 @@ func ExtractSections(cfg RootConfig) ExtractSectionsResult {
 		MetricConfig:      cfg.GetMetricConfig(),
 		SecureConfig:      cfg.GetSecureConfig(),
 		SecureTokenConfig: cfg.GetSecureConfig().GetToken(),
+		AuditConfig:       cfg.GetAuditConfig(),
 	}
 	return result
 }
@@ -52,6 +54,7 @@ type RootConfig interface {
 	GetTraceConfig() TraceConfig
 	GetMetricConfig() MetricConfig
 	GetSecureConfig() SecureConfig
+	GetAuditConfig() AuditConfig
 }
 
 type ServerConfig interface {
@@ -64,6 +67,7 @@ type ServerConfig interface {
 	GetRedisConfig() ServerRedisConfig
 	GetMinIOConfig() ServerMinIOConfig
 	GetNATSConfig() ServerNATSConfig
+	GetResourceConfig() ServerResourceConfig
 }
 
 type ServerHTTPConfig interface {
@@ -93,6 +97,13 @@ type ServerNATSConfig interface {
 	GetNoEcho() bool
 }
 
+type ServerResourceConfig interface {
+	GetNamespace() string
+	GetEnvironment() string
+	GetAttributes() map[string]string
+	GetDetectors() []string
+}
+
 type SnowflakeConfig interface {
 	GetIdEpoch() int64
 	GetClusterId() int64
@@ -106,10 +117,50 @@ type SnowflakeConfig interface {
 type LoggingConfig interface {
 	GetZapLogger() LoggingZapLoggerConfig
 	GetSlogLogger() LoggingSlogLoggerConfig
+	GetExporterConfig() LoggingExporterConfig
+	GetLevels() map[string]string
+	GetSinks() []LoggingSinkConfig
+	GetSamplingConfig() LoggingSamplingConfig
+	GetPayloadConfig() LoggingPayloadConfig
+}
+
+type LoggingPayloadConfig interface {
+	GetMethods() []string
+	GetRedactFields() []string
+	GetMaxSize() int
+}
+
+type LoggingSamplingConfig interface {
+	GetEnabled() bool
+	GetInterval() time.Duration
+	GetFirst() int
+	GetThereafter() int
+}
+
+type LoggingSinkConfig interface {
+	GetType() string
+	GetFormat() string
+	GetLevel() slog.Level
+	GetPath() string
+	GetMaxSize() int
+	GetMaxAge() int
+	GetMaxBackups() int
+	GetCompress() bool
+	GetLocalTime() bool
 }
 
 type LoggingZapLoggerConfig interface {
 	GetPreset() string
+	GetEncoding() string
+	GetLevel() string
+	GetDisableCaller() bool
+	GetStacktraceLevel() string
+	GetTimeEncoder() string
+	GetLevelEncoder() string
+	GetDurationEncoder() string
+	GetInitialFields() map[string]any
+	GetOutputPaths() []string
+	GetErrorOutputPaths() []string
 }
 
 type LoggingSlogLoggerConfig interface {
@@ -118,28 +169,161 @@ type LoggingSlogLoggerConfig interface {
 	GetLeveler() slog.Leveler
 }
 
+type LoggingExporterConfig interface {
+	GetProtocol() string
+	GetEndpoint() string
+	GetInsecure() bool
+	GetURLPath() string
+	GetHeaders() map[string]string
+	GetCompression() string
+	GetTimeout() time.Duration
+	GetTLSConfig() ExporterTLSConfig
+	GetRetryConfig() ExporterRetryConfig
+	// GetExclusive returns whether logs are only sent to the exporter, instead of alongside stderr.
+	GetExclusive() bool
+}
+
 type TraceConfig interface {
 	GetExporterConfig() TraceExporterConfig
+	GetSamplerConfig() TraceSamplerConfig
+	GetPropagators() []string
 }
 
 type TraceExporterConfig interface {
 	GetProtocol() string
 	GetEndpoint() string
 	GetInsecure() bool
+	GetURLPath() string
+	GetHeaders() map[string]string
+	GetCompression() string
+	GetTimeout() time.Duration
+	GetTLSConfig() ExporterTLSConfig
+	GetRetryConfig() ExporterRetryConfig
+}
+
+type TraceSamplerConfig interface {
+	GetType() string
+	GetRatio() float64
+	GetRules() []TraceSamplerRuleConfig
+}
+
+type TraceSamplerRuleConfig interface {
+	GetMethod() string
+	GetType() string
+	GetRatio() float64
 }
 
 type MetricConfig interface {
 	GetExporterConfig() MetricExporterConfig
+	GetRuntimeMetrics() bool
+	GetProcessMetrics() bool
+	GetViews() []MetricViewConfig
+}
+
+type MetricViewConfig interface {
+	GetInstrument() string
+	GetMeter() string
+	GetRename() string
+	GetAllowAttributes() []string
+	GetDenyAttributes() []string
+	GetAggregation() string
+	GetBuckets() []float64
+	GetMaxSize() int32
+	GetMaxScale() int32
 }
 
 type MetricExporterConfig interface {
 	GetProtocol() string
 	GetEndpoint() string
 	GetInsecure() bool
+	GetURLPath() string
+	GetHeaders() map[string]string
+	GetCompression() string
+	GetTimeout() time.Duration
+	GetInterval() time.Duration
+	GetTLSConfig() ExporterTLSConfig
+	GetRetryConfig() ExporterRetryConfig
+}
+
+type ExporterTLSConfig interface {
+	GetCAFile() string
+	GetCertFile() string
+	GetKeyFile() string
+	GetServerName() string
+	GetInsecureSkipVerify() bool
+}
+
+type ExporterRetryConfig interface {
+	GetEnabled() bool
+	GetInitialInterval() time.Duration
+	GetMaxInterval() time.Duration
+	GetMaxElapsedTime() time.Duration
 }
 
 type SecureConfig interface {
 	GetToken() SecureTokenConfig
+	GetTelemetry() SecureTelemetryConfig
+	// GetPolicies returns authorization policies of methods, methods not matched by any policy are denied.
+	GetPolicies() []SecurePolicyConfig
+	// GetStrict returns whether servers fail at startup for methods without policies.
+	GetStrict() bool
+	GetRBAC() SecureRBACConfig
+}
+
+type SecureRBACConfig interface {
+	// GetStore returns the type of role store, config or bun.
+	GetStore() string
+	// GetCacheTTL returns how long resolved permissions are cached.
+	GetCacheTTL() time.Duration
+	// GetSubject returns the nats subject that invalidation messages are published to.
+	GetSubject() string
+	// GetRealms returns roles and bindings of realms, only for config store.
+	GetRealms() []SecureRBACRealmConfig
+}
+
+type SecureRBACRealmConfig interface {
+	GetName() string
+	GetRoles() []SecureRBACRoleConfig
+	GetBindings() []SecureRBACBindingConfig
+}
+
+type SecureRBACRoleConfig interface {
+	GetName() string
+	// GetParents returns names of roles that the role inherits permissions from.
+	GetParents() []string
+	GetPermissions() []string
+}
+
+type SecureRBACBindingConfig interface {
+	GetSubject() string
+	GetRoles() []string
+}
+
+type SecurePolicyConfig interface {
+	// GetMethod returns the full method name of the policy, it may contain glob patterns, such as "/pkg.Service/*".
+	GetMethod() string
+	GetPublic() bool
+	// GetAuthenticated returns whether the policy requires authenticated identities,
+	// it is redundant as policies other than public always require them.
+	GetAuthenticated() bool
+	GetSchema() string
+	GetRealm() string
+	GetScopes() []string
+	// GetPermissions returns the permissions required by the policy, resolved by the permission resolver of the authorizer.
+	GetPermissions() []string
+	GetExpression() string
+}
+
+type SecureTelemetryConfig interface {
+	// GetSpanAttributes returns whether identity attributes (schema, realm, client and scope) are set on spans.
+	GetSpanAttributes() bool
+	// GetSpanSubject returns whether the subject is set on spans as enduser.id, it is privacy-sensitive.
+	GetSpanSubject() bool
+	// GetLogSubject returns whether the subject is added to records logged during calls as enduser.id,
+	// it is enabled by default and can be disabled for privacy.
+	GetLogSubject() bool
+	// GetMetricAttributes returns whether calls are counted by schema, realm and client.
+	GetMetricAttributes() bool
 }
 
 type SecureTokenConfig interface {
@@ -153,3 +337,16 @@ type SecureTokenConfig interface {
 	GetPublicKey() []byte
 	GetPrivateKey() []byte
 }
+
+type AuditConfig interface {
+	GetSink() string
+	GetTable() string
+	GetSubject() string
+	GetBufferSize() int
+	GetBatchSize() int
+	GetFlushInterval() time.Duration
+	GetOverflow() string
+	GetBlockTimeout() time.Duration
+	GetMaxRequestSize() int
+	GetRedactFields() []string
+}
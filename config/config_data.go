@@ -16,6 +16,7 @@ type rootConfig struct {
 	Trace     *traceConfig
 	Metric    *metricConfig
 	Secure    *secureConfig
+	Audit     *auditConfig
 }
 
 func (c *rootConfig) GetServerConfig() ServerConfig {
@@ -60,15 +61,23 @@ func (c *rootConfig) GetSecureConfig() SecureConfig {
 	return c.Secure
 }
 
+func (c *rootConfig) GetAuditConfig() AuditConfig {
+	if c.Audit == nil {
+		c.Audit = &auditConfig{}
+	}
+	return c.Audit
+}
+
 type serverConfig struct {
-	Debug   *bool
-	Name    *string
-	Version *string
-	HTTP    *serverHTTPConfig
-	DB      *serverDBConfig
-	Redis   *serverRedisConfig
-	MinIO   *serverMinIOConfig
-	NATS    *serverNATSConfig
+	Debug    *bool
+	Name     *string
+	Version  *string
+	HTTP     *serverHTTPConfig
+	DB       *serverDBConfig
+	Redis    *serverRedisConfig
+	MinIO    *serverMinIOConfig
+	NATS     *serverNATSConfig
+	Resource *serverResourceConfig
 }
 
 func (c *serverConfig) GetDebug() bool {
@@ -140,6 +149,13 @@ func (c *serverConfig) GetNATSConfig() ServerNATSConfig {
 	return c.NATS
 }
 
+func (c *serverConfig) GetResourceConfig() ServerResourceConfig {
+	if c.Resource == nil {
+		c.Resource = &serverResourceConfig{}
+	}
+	return c.Resource
+}
+
 type serverHTTPConfig struct {
 	Addr *string
 	Cors *serverHTTPCorsConfig
@@ -324,6 +340,41 @@ func (c *serverNATSConfig) GetNoEcho() bool {
 	}
 }
 
+type serverResourceConfig struct {
+	Namespace   *string
+	Environment *string
+	Attributes  map[string]string
+	Detectors   *[]string
+}
+
+func (c *serverResourceConfig) GetNamespace() string {
+	if c.Namespace == nil {
+		return ""
+	} else {
+		return *c.Namespace
+	}
+}
+
+func (c *serverResourceConfig) GetEnvironment() string {
+	if c.Environment == nil {
+		return ""
+	} else {
+		return *c.Environment
+	}
+}
+
+func (c *serverResourceConfig) GetAttributes() map[string]string {
+	return c.Attributes
+}
+
+func (c *serverResourceConfig) GetDetectors() []string {
+	if c.Detectors == nil {
+		return []string{"host", "os", "process", "container", "env"}
+	} else {
+		return *c.Detectors
+	}
+}
+
 type snowflakeConfig struct {
 	IdEpoch       *int64  `yaml:"id-epoch"`
 	ClusterId     *int64  `yaml:"cluster-id"`
@@ -393,6 +444,11 @@ func (c *snowflakeConfig) GetSequenceBits() int32 {
 type loggingConfig struct {
 	SlogLogger *loggingSlogLoggerConfig `yaml:"slog-logger"`
 	ZapLogger  *loggingZapLoggerConfig  `yaml:"zap-logger"`
+	Exporter   *loggingExporterConfig
+	Levels     *map[string]string
+	Sinks      []*loggingSinkConfig
+	Sampling   *loggingSamplingConfig
+	Payload    *loggingPayloadConfig
 }
 
 func (c *loggingConfig) GetSlogLogger() LoggingSlogLoggerConfig {
@@ -409,6 +465,209 @@ func (c *loggingConfig) GetZapLogger() LoggingZapLoggerConfig {
 	return c.ZapLogger
 }
 
+func (c *loggingConfig) GetExporterConfig() LoggingExporterConfig {
+	if c.Exporter == nil {
+		c.Exporter = &loggingExporterConfig{}
+	}
+	return c.Exporter
+}
+
+func (c *loggingConfig) GetLevels() map[string]string {
+	if c.Levels == nil {
+		return map[string]string{}
+	} else {
+		return *c.Levels
+	}
+}
+
+func (c *loggingConfig) GetSinks() []LoggingSinkConfig {
+	sinks := make([]LoggingSinkConfig, len(c.Sinks))
+	for i, sink := range c.Sinks {
+		sinks[i] = sink
+	}
+	return sinks
+}
+
+func (c *loggingConfig) GetSamplingConfig() LoggingSamplingConfig {
+	if c.Sampling == nil {
+		c.Sampling = &loggingSamplingConfig{}
+	}
+	return c.Sampling
+}
+
+func (c *loggingConfig) GetPayloadConfig() LoggingPayloadConfig {
+	if c.Payload == nil {
+		c.Payload = &loggingPayloadConfig{}
+	}
+	return c.Payload
+}
+
+type loggingPayloadConfig struct {
+	Methods      *[]string
+	RedactFields *[]string `yaml:"redact-fields"`
+	MaxSize      *int      `yaml:"max-size"`
+}
+
+func (c *loggingPayloadConfig) GetMethods() []string {
+	if c.Methods == nil {
+		return nil
+	} else {
+		return *c.Methods
+	}
+}
+
+func (c *loggingPayloadConfig) GetRedactFields() []string {
+	if c.RedactFields == nil {
+		return nil
+	} else {
+		return *c.RedactFields
+	}
+}
+
+func (c *loggingPayloadConfig) GetMaxSize() int {
+	if c.MaxSize == nil {
+		return 4096
+	} else {
+		return *c.MaxSize
+	}
+}
+
+type loggingSamplingConfig struct {
+	Enabled    *bool
+	Interval   *time.Duration
+	First      *int
+	Thereafter *int
+}
+
+func (c *loggingSamplingConfig) GetEnabled() bool {
+	if c.Enabled == nil {
+		return false
+	} else {
+		return *c.Enabled
+	}
+}
+
+func (c *loggingSamplingConfig) GetInterval() time.Duration {
+	if c.Interval == nil {
+		return time.Second
+	} else {
+		return *c.Interval
+	}
+}
+
+func (c *loggingSamplingConfig) GetFirst() int {
+	if c.First == nil {
+		return 100
+	} else {
+		return *c.First
+	}
+}
+
+func (c *loggingSamplingConfig) GetThereafter() int {
+	if c.Thereafter == nil {
+		return 100
+	} else {
+		return *c.Thereafter
+	}
+}
+
+type loggingSinkConfig struct {
+	Type       *string
+	Format     *string
+	Level      *slog.Level
+	Path       *string
+	MaxSize    *int `yaml:"max-size"`
+	MaxAge     *int `yaml:"max-age"`
+	MaxBackups *int `yaml:"max-backups"`
+	Compress   *bool
+	LocalTime  *bool `yaml:"local-time"`
+}
+
+func (c *loggingSinkConfig) GetType() string {
+	if c.Type == nil {
+		return "stderr"
+	} else {
+		return *c.Type
+	}
+}
+
+func (c *loggingSinkConfig) GetFormat() string {
+	if c.Format == nil {
+		return "json"
+	} else {
+		return *c.Format
+	}
+}
+
+func (c *loggingSinkConfig) GetLevel() slog.Level {
+	if c.Level == nil {
+		return slog.LevelDebug
+	} else {
+		return *c.Level
+	}
+}
+
+func (c *loggingSinkConfig) GetPath() string {
+	if c.Path == nil {
+		return "./logs/app.log"
+	} else {
+		return *c.Path
+	}
+}
+
+func (c *loggingSinkConfig) GetMaxSize() int {
+	if c.MaxSize == nil {
+		return 100
+	} else {
+		return *c.MaxSize
+	}
+}
+
+func (c *loggingSinkConfig) GetMaxAge() int {
+	if c.MaxAge == nil {
+		return 7
+	} else {
+		return *c.MaxAge
+	}
+}
+
+func (c *loggingSinkConfig) GetMaxBackups() int {
+	if c.MaxBackups == nil {
+		return 10
+	} else {
+		return *c.MaxBackups
+	}
+}
+
+func (c *loggingSinkConfig) GetCompress() bool {
+	if c.Compress == nil {
+		return false
+	} else {
+		return *c.Compress
+	}
+}
+
+func (c *loggingSinkConfig) GetLocalTime() bool {
+	if c.LocalTime == nil {
+		return false
+	} else {
+		return *c.LocalTime
+	}
+}
+
+type loggingExporterConfig struct {
+	exporterConfig `yaml:",inline"`
+	Exclusive      *bool
+}
+
+func (c *loggingExporterConfig) GetExclusive() bool {
+	if c.Exclusive == nil {
+		return false
+	} else {
+		return *c.Exclusive
+	}
+}
+
 type loggingSlogLoggerConfig struct {
 	Handler   *string
 	AddSource *bool
@@ -440,7 +699,17 @@ func (c *loggingSlogLoggerConfig) GetLeveler() slog.Leveler {
 }
 
 type loggingZapLoggerConfig struct {
-	Preset *string
+	Preset           *string
+	Encoding         *string
+	Level            *string
+	DisableCaller    *bool           `yaml:"disable-caller"`
+	StacktraceLevel  *string         `yaml:"stacktrace-level"`
+	TimeEncoder      *string         `yaml:"time-encoder"`
+	LevelEncoder     *string         `yaml:"level-encoder"`
+	DurationEncoder  *string         `yaml:"duration-encoder"`
+	InitialFields    *map[string]any `yaml:"initial-fields"`
+	OutputPaths      *[]string       `yaml:"output-paths"`
+	ErrorOutputPaths *[]string       `yaml:"error-output-paths"`
 }
 
 func (c *loggingZapLoggerConfig) GetPreset() string {
@@ -451,168 +720,838 @@ func (c *loggingZapLoggerConfig) GetPreset() string {
 	}
 }
 
-type traceConfig struct {
-	Exporter *traceExporterConfig
+func (c *loggingZapLoggerConfig) GetEncoding() string {
+	if c.Encoding == nil {
+		return ""
+	} else {
+		return *c.Encoding
+	}
 }
 
-func (c *traceConfig) GetExporterConfig() TraceExporterConfig {
-	return c.Exporter
+func (c *loggingZapLoggerConfig) GetLevel() string {
+	if c.Level == nil {
+		return ""
+	} else {
+		return *c.Level
+	}
 }
 
-type traceExporterConfig struct {
-	Protocol *string
-	Endpoint *string
-	Insecure *bool
+func (c *loggingZapLoggerConfig) GetDisableCaller() bool {
+	if c.DisableCaller == nil {
+		return false
+	} else {
+		return *c.DisableCaller
+	}
 }
 
-func (c *traceExporterConfig) GetProtocol() string {
-	if c.Protocol == nil {
-		return "noop"
+func (c *loggingZapLoggerConfig) GetStacktraceLevel() string {
+	if c.StacktraceLevel == nil {
+		return ""
 	} else {
-		return *c.Protocol
+		return *c.StacktraceLevel
 	}
 }
 
-func (c *traceExporterConfig) GetEndpoint() string {
-	if c.Endpoint == nil {
+func (c *loggingZapLoggerConfig) GetTimeEncoder() string {
+	if c.TimeEncoder == nil {
 		return ""
 	} else {
-		return *c.Endpoint
+		return *c.TimeEncoder
 	}
 }
 
-func (c *traceExporterConfig) GetInsecure() bool {
-	if c.Insecure == nil {
-		return false
+func (c *loggingZapLoggerConfig) GetLevelEncoder() string {
+	if c.LevelEncoder == nil {
+		return ""
 	} else {
-		return *c.Insecure
+		return *c.LevelEncoder
 	}
 }
 
-type metricConfig struct {
-	Exporter *metricExporterConfig
+func (c *loggingZapLoggerConfig) GetDurationEncoder() string {
+	if c.DurationEncoder == nil {
+		return ""
+	} else {
+		return *c.DurationEncoder
+	}
 }
 
-func (c *metricConfig) GetExporterConfig() MetricExporterConfig {
-	return c.Exporter
+func (c *loggingZapLoggerConfig) GetInitialFields() map[string]any {
+	if c.InitialFields == nil {
+		return nil
+	} else {
+		return *c.InitialFields
+	}
 }
 
-type metricExporterConfig struct {
-	Protocol *string
-	Endpoint *string
-	Insecure *bool
+func (c *loggingZapLoggerConfig) GetOutputPaths() []string {
+	if c.OutputPaths == nil {
+		return nil
+	} else {
+		return *c.OutputPaths
+	}
 }
 
-func (c *metricExporterConfig) GetProtocol() string {
-	if c.Protocol == nil {
-		return "noop"
+func (c *loggingZapLoggerConfig) GetErrorOutputPaths() []string {
+	if c.ErrorOutputPaths == nil {
+		return nil
 	} else {
-		return *c.Protocol
+		return *c.ErrorOutputPaths
 	}
 }
 
-func (c *metricExporterConfig) GetEndpoint() string {
-	if c.Endpoint == nil {
-		return ""
+type traceConfig struct {
+	Exporter    *traceExporterConfig
+	Sampler     *traceSamplerConfig
+	Propagators *[]string
+}
+
+func (c *traceConfig) GetExporterConfig() TraceExporterConfig {
+	if c.Exporter == nil {
+		c.Exporter = &traceExporterConfig{}
+	}
+	return c.Exporter
+}
+
+func (c *traceConfig) GetSamplerConfig() TraceSamplerConfig {
+	if c.Sampler == nil {
+		c.Sampler = &traceSamplerConfig{}
+	}
+	return c.Sampler
+}
+
+func (c *traceConfig) GetPropagators() []string {
+	if c.Propagators == nil {
+		return []string{"tracecontext", "baggage"}
 	} else {
-		return *c.Endpoint
+		return *c.Propagators
 	}
 }
 
-func (c *metricExporterConfig) GetInsecure() bool {
-	if c.Insecure == nil {
-		return false
+type traceExporterConfig struct {
+	exporterConfig `yaml:",inline"`
+}
+
+type traceSamplerConfig struct {
+	Type  *string
+	Ratio *float64
+	Rules []*traceSamplerRuleConfig
+}
+
+func (c *traceSamplerConfig) GetType() string {
+	if c.Type == nil {
+		return "always"
 	} else {
-		return *c.Insecure
+		return *c.Type
 	}
 }
 
-type secureConfig struct {
-	Token *secureTokenConfig
+func (c *traceSamplerConfig) GetRatio() float64 {
+	if c.Ratio == nil {
+		return 1
+	} else {
+		return *c.Ratio
+	}
 }
 
-func (c *secureConfig) GetToken() SecureTokenConfig {
-	if c.Token == nil {
-		c.Token = &secureTokenConfig{}
+func (c *traceSamplerConfig) GetRules() []TraceSamplerRuleConfig {
+	rules := make([]TraceSamplerRuleConfig, len(c.Rules))
+	for i, rule := range c.Rules {
+		rules[i] = rule
 	}
-	return c.Token
+	return rules
 }
 
-type secureTokenConfig struct {
-	Store           *string
-	Bucket          *string
-	AccessTokenTTL  *time.Duration `yaml:"access-token-ttl"`
-	RefreshTokenTTL *time.Duration `yaml:"refresh-token-ttl"`
-	Issuer          *string
-	Audience        *string
-	SigningMethod   *string `yaml:"signing-method"`
-	PublicKeyFile   *string `yaml:"public-key-file"`
-	PrivateKeyFile  *string `yaml:"private-key-file"`
-	PublicKeyValue  *string `yaml:"public-key-value"`
-	PrivateKeyValue *string `yaml:"private-key-value"`
+type traceSamplerRuleConfig struct {
+	Method *string
+	Type   *string
+	Ratio  *float64
 }
 
-func (c *secureTokenConfig) GetStore() string {
-	if c.Store == nil {
-		return "redis"
+func (c *traceSamplerRuleConfig) GetMethod() string {
+	if c.Method == nil {
+		return "*"
 	} else {
-		return *c.Store
+		return *c.Method
 	}
 }
 
-func (c *secureTokenConfig) GetBucket() string {
-	if c.Bucket == nil {
-		return "tokens"
+func (c *traceSamplerRuleConfig) GetType() string {
+	if c.Type == nil {
+		return "always"
 	} else {
-		return *c.Bucket
+		return *c.Type
 	}
 }
 
-func (c *secureTokenConfig) GetAccessTokenTTL() time.Duration {
-	if c.AccessTokenTTL == nil {
-		return 2 * 24 * time.Hour // default to 2 days
+func (c *traceSamplerRuleConfig) GetRatio() float64 {
+	if c.Ratio == nil {
+		return 1
 	} else {
-		return *c.AccessTokenTTL
+		return *c.Ratio
 	}
 }
 
-func (c *secureTokenConfig) GetRefreshTokenTTL() time.Duration {
-	if c.RefreshTokenTTL == nil {
-		return 7 * 24 * time.Hour // default to 7 days
+type metricConfig struct {
+	Exporter       *metricExporterConfig
+	RuntimeMetrics *bool `yaml:"runtime-metrics"`
+	ProcessMetrics *bool `yaml:"process-metrics"`
+	Views          []*metricViewConfig
+}
+
+func (c *metricConfig) GetExporterConfig() MetricExporterConfig {
+	if c.Exporter == nil {
+		c.Exporter = &metricExporterConfig{}
+	}
+	return c.Exporter
+}
+
+func (c *metricConfig) GetRuntimeMetrics() bool {
+	if c.RuntimeMetrics == nil {
+		return true
 	} else {
-		return *c.RefreshTokenTTL
+		return *c.RuntimeMetrics
 	}
 }
 
-func (c *secureTokenConfig) GetIssuer() string {
-	if c.Issuer == nil {
-		return "unnamed-issuer"
+func (c *metricConfig) GetProcessMetrics() bool {
+	if c.ProcessMetrics == nil {
+		return true
 	} else {
-		return *c.Issuer
+		return *c.ProcessMetrics
 	}
 }
 
-func (c *secureTokenConfig) GetAudience() string {
-	if c.Audience == nil {
-		return "unnamed-audience"
+func (c *metricConfig) GetViews() []MetricViewConfig {
+	views := make([]MetricViewConfig, len(c.Views))
+	for i, view := range c.Views {
+		views[i] = view
+	}
+	return views
+}
+
+type metricViewConfig struct {
+	Instrument      *string
+	Meter           *string
+	Rename          *string
+	AllowAttributes *[]string `yaml:"allow-attributes"`
+	DenyAttributes  *[]string `yaml:"deny-attributes"`
+	Aggregation     *string
+	Buckets         *[]float64
+	MaxSize         *int32 `yaml:"max-size"`
+	MaxScale        *int32 `yaml:"max-scale"`
+}
+
+func (c *metricViewConfig) GetInstrument() string {
+	if c.Instrument == nil {
+		return ""
 	} else {
-		return *c.Audience
+		return *c.Instrument
 	}
 }
 
-func (c *secureTokenConfig) GetSigningMethod() string {
-	if c.SigningMethod == nil {
-		return "RS256"
+func (c *metricViewConfig) GetMeter() string {
+	if c.Meter == nil {
+		return ""
 	} else {
-		return *c.SigningMethod
+		return *c.Meter
 	}
 }
 
-func (c *secureTokenConfig) GetPublicKey() []byte {
-	if c.PublicKeyValue != nil {
-		return []byte(*c.PublicKeyValue)
+func (c *metricViewConfig) GetRename() string {
+	if c.Rename == nil {
+		return ""
+	} else {
+		return *c.Rename
 	}
-	if c.PublicKeyFile != nil {
+}
+
+func (c *metricViewConfig) GetAllowAttributes() []string {
+	if c.AllowAttributes == nil {
+		return nil
+	} else {
+		return *c.AllowAttributes
+	}
+}
+
+func (c *metricViewConfig) GetDenyAttributes() []string {
+	if c.DenyAttributes == nil {
+		return nil
+	} else {
+		return *c.DenyAttributes
+	}
+}
+
+func (c *metricViewConfig) GetAggregation() string {
+	if c.Aggregation == nil {
+		return "default"
+	} else {
+		return *c.Aggregation
+	}
+}
+
+func (c *metricViewConfig) GetBuckets() []float64 {
+	if c.Buckets == nil {
+		return nil
+	} else {
+		return *c.Buckets
+	}
+}
+
+func (c *metricViewConfig) GetMaxSize() int32 {
+	if c.MaxSize == nil {
+		return 160
+	} else {
+		return *c.MaxSize
+	}
+}
+
+func (c *metricViewConfig) GetMaxScale() int32 {
+	if c.MaxScale == nil {
+		return 20
+	} else {
+		return *c.MaxScale
+	}
+}
+
+type metricExporterConfig struct {
+	exporterConfig `yaml:",inline"`
+	Interval       *time.Duration
+}
+
+func (c *metricExporterConfig) GetInterval() time.Duration {
+	if c.Interval == nil {
+		return 5 * time.Second
+	} else {
+		return *c.Interval
+	}
+}
+
+// exporterConfig contains the common options of OTLP exporters.
+type exporterConfig struct {
+	Protocol    *string
+	Endpoint    *string
+	Insecure    *bool
+	URLPath     *string `yaml:"url-path"`
+	Headers     map[string]string
+	Compression *string
+	Timeout     *time.Duration
+	TLS         *exporterTLSConfig
+	Retry       *exporterRetryConfig
+}
+
+func (c *exporterConfig) GetProtocol() string {
+	if c.Protocol == nil {
+		return "noop"
+	} else {
+		return *c.Protocol
+	}
+}
+
+func (c *exporterConfig) GetEndpoint() string {
+	if c.Endpoint == nil {
+		return ""
+	} else {
+		return *c.Endpoint
+	}
+}
+
+func (c *exporterConfig) GetInsecure() bool {
+	if c.Insecure == nil {
+		return false
+	} else {
+		return *c.Insecure
+	}
+}
+
+func (c *exporterConfig) GetURLPath() string {
+	if c.URLPath == nil {
+		return ""
+	} else {
+		return *c.URLPath
+	}
+}
+
+// GetHeaders returns the headers with secrets interpolated when the config is loaded, see expandSecret.
+func (c *exporterConfig) GetHeaders() map[string]string {
+	headers := make(map[string]string, len(c.Headers))
+	for k, v := range c.Headers {
+		headers[k] = v
+	}
+	return headers
+}
+
+func (c *exporterConfig) GetCompression() string {
+	if c.Compression == nil {
+		return "none"
+	} else {
+		return *c.Compression
+	}
+}
+
+func (c *exporterConfig) GetTimeout() time.Duration {
+	if c.Timeout == nil {
+		return 2 * time.Second
+	} else {
+		return *c.Timeout
+	}
+}
+
+func (c *exporterConfig) GetTLSConfig() ExporterTLSConfig {
+	if c.TLS == nil {
+		c.TLS = &exporterTLSConfig{}
+	}
+	return c.TLS
+}
+
+func (c *exporterConfig) GetRetryConfig() ExporterRetryConfig {
+	if c.Retry == nil {
+		c.Retry = &exporterRetryConfig{}
+	}
+	return c.Retry
+}
+
+type exporterTLSConfig struct {
+	CAFile             *string `yaml:"ca-file"`
+	CertFile           *string `yaml:"cert-file"`
+	KeyFile            *string `yaml:"key-file"`
+	ServerName         *string `yaml:"server-name"`
+	InsecureSkipVerify *bool   `yaml:"insecure-skip-verify"`
+}
+
+func (c *exporterTLSConfig) GetCAFile() string {
+	if c.CAFile == nil {
+		return ""
+	} else {
+		return *c.CAFile
+	}
+}
+
+func (c *exporterTLSConfig) GetCertFile() string {
+	if c.CertFile == nil {
+		return ""
+	} else {
+		return *c.CertFile
+	}
+}
+
+func (c *exporterTLSConfig) GetKeyFile() string {
+	if c.KeyFile == nil {
+		return ""
+	} else {
+		return *c.KeyFile
+	}
+}
+
+func (c *exporterTLSConfig) GetServerName() string {
+	if c.ServerName == nil {
+		return ""
+	} else {
+		return *c.ServerName
+	}
+}
+
+func (c *exporterTLSConfig) GetInsecureSkipVerify() bool {
+	if c.InsecureSkipVerify == nil {
+		return false
+	} else {
+		return *c.InsecureSkipVerify
+	}
+}
+
+type exporterRetryConfig struct {
+	Enabled         *bool
+	InitialInterval *time.Duration `yaml:"initial-interval"`
+	MaxInterval     *time.Duration `yaml:"max-interval"`
+	MaxElapsedTime  *time.Duration `yaml:"max-elapsed-time"`
+}
+
+func (c *exporterRetryConfig) GetEnabled() bool {
+	if c.Enabled == nil {
+		return true
+	} else {
+		return *c.Enabled
+	}
+}
+
+func (c *exporterRetryConfig) GetInitialInterval() time.Duration {
+	if c.InitialInterval == nil {
+		return 5 * time.Second
+	} else {
+		return *c.InitialInterval
+	}
+}
+
+func (c *exporterRetryConfig) GetMaxInterval() time.Duration {
+	if c.MaxInterval == nil {
+		return 30 * time.Second
+	} else {
+		return *c.MaxInterval
+	}
+}
+
+func (c *exporterRetryConfig) GetMaxElapsedTime() time.Duration {
+	if c.MaxElapsedTime == nil {
+		return time.Minute
+	} else {
+		return *c.MaxElapsedTime
+	}
+}
+
+type secureConfig struct {
+	Token     *secureTokenConfig
+	Telemetry *secureTelemetryConfig
+	Policies  []*securePolicyConfig
+	Strict    *bool
+	RBAC      *secureRBACConfig `yaml:"rbac"`
+}
+
+func (c *secureConfig) GetToken() SecureTokenConfig {
+	if c.Token == nil {
+		c.Token = &secureTokenConfig{}
+	}
+	return c.Token
+}
+
+func (c *secureConfig) GetTelemetry() SecureTelemetryConfig {
+	if c.Telemetry == nil {
+		c.Telemetry = &secureTelemetryConfig{}
+	}
+	return c.Telemetry
+}
+
+func (c *secureConfig) GetPolicies() []SecurePolicyConfig {
+	policies := make([]SecurePolicyConfig, len(c.Policies))
+	for i, policy := range c.Policies {
+		policies[i] = policy
+	}
+	return policies
+}
+
+func (c *secureConfig) GetStrict() bool {
+	if c.Strict == nil {
+		return false
+	} else {
+		return *c.Strict
+	}
+}
+
+func (c *secureConfig) GetRBAC() SecureRBACConfig {
+	if c.RBAC == nil {
+		c.RBAC = &secureRBACConfig{}
+	}
+	return c.RBAC
+}
+
+type secureRBACConfig struct {
+	Store    *string
+	CacheTTL *time.Duration `yaml:"cache-ttl"`
+	Subject  *string
+	Realms   []*secureRBACRealmConfig
+}
+
+func (c *secureRBACConfig) GetStore() string {
+	if c.Store == nil {
+		return "config"
+	} else {
+		return *c.Store
+	}
+}
+
+func (c *secureRBACConfig) GetCacheTTL() time.Duration {
+	if c.CacheTTL == nil {
+		return 5 * time.Minute
+	} else {
+		return *c.CacheTTL
+	}
+}
+
+func (c *secureRBACConfig) GetSubject() string {
+	if c.Subject == nil {
+		return "rbac.invalidate"
+	} else {
+		return *c.Subject
+	}
+}
+
+func (c *secureRBACConfig) GetRealms() []SecureRBACRealmConfig {
+	realms := make([]SecureRBACRealmConfig, len(c.Realms))
+	for i, realm := range c.Realms {
+		realms[i] = realm
+	}
+	return realms
+}
+
+type secureRBACRealmConfig struct {
+	Name     *string
+	Roles    []*secureRBACRoleConfig
+	Bindings []*secureRBACBindingConfig
+}
+
+func (c *secureRBACRealmConfig) GetName() string {
+	if c.Name == nil {
+		return ""
+	} else {
+		return *c.Name
+	}
+}
+
+func (c *secureRBACRealmConfig) GetRoles() []SecureRBACRoleConfig {
+	roles := make([]SecureRBACRoleConfig, len(c.Roles))
+	for i, role := range c.Roles {
+		roles[i] = role
+	}
+	return roles
+}
+
+func (c *secureRBACRealmConfig) GetBindings() []SecureRBACBindingConfig {
+	bindings := make([]SecureRBACBindingConfig, len(c.Bindings))
+	for i, binding := range c.Bindings {
+		bindings[i] = binding
+	}
+	return bindings
+}
+
+type secureRBACRoleConfig struct {
+	Name        *string
+	Parents     *[]string
+	Permissions *[]string
+}
+
+func (c *secureRBACRoleConfig) GetName() string {
+	if c.Name == nil {
+		return ""
+	} else {
+		return *c.Name
+	}
+}
+
+func (c *secureRBACRoleConfig) GetParents() []string {
+	if c.Parents == nil {
+		return nil
+	} else {
+		return *c.Parents
+	}
+}
+
+func (c *secureRBACRoleConfig) GetPermissions() []string {
+	if c.Permissions == nil {
+		return nil
+	} else {
+		return *c.Permissions
+	}
+}
+
+type secureRBACBindingConfig struct {
+	Subject *string
+	Roles   *[]string
+}
+
+func (c *secureRBACBindingConfig) GetSubject() string {
+	if c.Subject == nil {
+		return ""
+	} else {
+		return *c.Subject
+	}
+}
+
+func (c *secureRBACBindingConfig) GetRoles() []string {
+	if c.Roles == nil {
+		return nil
+	} else {
+		return *c.Roles
+	}
+}
+
+type securePolicyConfig struct {
+	Method        *string
+	Public        *bool
+	Authenticated *bool
+	Schema        *string
+	Realm         *string
+	Scopes        *[]string
+	Permissions   *[]string
+	Expression    *string
+}
+
+func (c *securePolicyConfig) GetMethod() string {
+	if c.Method == nil {
+		return ""
+	} else {
+		return *c.Method
+	}
+}
+
+func (c *securePolicyConfig) GetPublic() bool {
+	if c.Public == nil {
+		return false
+	} else {
+		return *c.Public
+	}
+}
+
+func (c *securePolicyConfig) GetAuthenticated() bool {
+	if c.Authenticated == nil {
+		return false
+	} else {
+		return *c.Authenticated
+	}
+}
+
+func (c *securePolicyConfig) GetSchema() string {
+	if c.Schema == nil {
+		return ""
+	} else {
+		return *c.Schema
+	}
+}
+
+func (c *securePolicyConfig) GetRealm() string {
+	if c.Realm == nil {
+		return ""
+	} else {
+		return *c.Realm
+	}
+}
+
+func (c *securePolicyConfig) GetScopes() []string {
+	if c.Scopes == nil {
+		return nil
+	} else {
+		return *c.Scopes
+	}
+}
+
+func (c *securePolicyConfig) GetPermissions() []string {
+	if c.Permissions == nil {
+		return nil
+	} else {
+		return *c.Permissions
+	}
+}
+
+func (c *securePolicyConfig) GetExpression() string {
+	if c.Expression == nil {
+		return ""
+	} else {
+		return *c.Expression
+	}
+}
+
+type secureTelemetryConfig struct {
+	SpanAttributes   *bool `yaml:"span-attributes"`
+	SpanSubject      *bool `yaml:"span-subject"`
+	LogSubject       *bool `yaml:"log-subject"`
+	MetricAttributes *bool `yaml:"metric-attributes"`
+}
+
+func (c *secureTelemetryConfig) GetSpanAttributes() bool {
+	if c.SpanAttributes == nil {
+		return true
+	} else {
+		return *c.SpanAttributes
+	}
+}
+
+func (c *secureTelemetryConfig) GetSpanSubject() bool {
+	if c.SpanSubject == nil {
+		return false
+	} else {
+		return *c.SpanSubject
+	}
+}
+
+func (c *secureTelemetryConfig) GetLogSubject() bool {
+	if c.LogSubject == nil {
+		return true
+	} else {
+		return *c.LogSubject
+	}
+}
+
+func (c *secureTelemetryConfig) GetMetricAttributes() bool {
+	if c.MetricAttributes == nil {
+		return false
+	} else {
+		return *c.MetricAttributes
+	}
+}
+
+type secureTokenConfig struct {
+	Store           *string
+	Bucket          *string
+	AccessTokenTTL  *time.Duration `yaml:"access-token-ttl"`
+	RefreshTokenTTL *time.Duration `yaml:"refresh-token-ttl"`
+	Issuer          *string
+	Audience        *string
+	SigningMethod   *string `yaml:"signing-method"`
+	PublicKeyFile   *string `yaml:"public-key-file"`
+	PrivateKeyFile  *string `yaml:"private-key-file"`
+	PublicKeyValue  *string `yaml:"public-key-value"`
+	PrivateKeyValue *string `yaml:"private-key-value"`
+}
+
+func (c *secureTokenConfig) GetStore() string {
+	if c.Store == nil {
+		return "redis"
+	} else {
+		return *c.Store
+	}
+}
+
+func (c *secureTokenConfig) GetBucket() string {
+	if c.Bucket == nil {
+		return "tokens"
+	} else {
+		return *c.Bucket
+	}
+}
+
+func (c *secureTokenConfig) GetAccessTokenTTL() time.Duration {
+	if c.AccessTokenTTL == nil {
+		return 2 * 24 * time.Hour // default to 2 days
+	} else {
+		return *c.AccessTokenTTL
+	}
+}
+
+func (c *secureTokenConfig) GetRefreshTokenTTL() time.Duration {
+	if c.RefreshTokenTTL == nil {
+		return 7 * 24 * time.Hour // default to 7 days
+	} else {
+		return *c.RefreshTokenTTL
+	}
+}
+
+func (c *secureTokenConfig) GetIssuer() string {
+	if c.Issuer == nil {
+		return "unnamed-issuer"
+	} else {
+		return *c.Issuer
+	}
+}
+
+func (c *secureTokenConfig) GetAudience() string {
+	if c.Audience == nil {
+		return "unnamed-audience"
+	} else {
+		return *c.Audience
+	}
+}
+
+func (c *secureTokenConfig) GetSigningMethod() string {
+	if c.SigningMethod == nil {
+		return "RS256"
+	} else {
+		return *c.SigningMethod
+	}
+}
+
+func (c *secureTokenConfig) GetPublicKey() []byte {
+	if c.PublicKeyValue != nil {
+		return []byte(*c.PublicKeyValue)
+	}
+	if c.PublicKeyFile != nil {
 		body, err := os.ReadFile(*c.PublicKeyFile)
 		if err != nil {
 			panic(err)
@@ -635,3 +1574,96 @@ func (c *secureTokenConfig) GetPrivateKey() []byte {
 	}
 	return nil
 }
+
+type auditConfig struct {
+	Sink           *string
+	Table          *string
+	Subject        *string
+	BufferSize     *int           `yaml:"buffer-size"`
+	BatchSize      *int           `yaml:"batch-size"`
+	FlushInterval  *time.Duration `yaml:"flush-interval"`
+	Overflow       *string
+	BlockTimeout   *time.Duration `yaml:"block-timeout"`
+	MaxRequestSize *int           `yaml:"max-request-size"`
+	RedactFields   *[]string      `yaml:"redact-fields"`
+}
+
+func (c *auditConfig) GetSink() string {
+	if c.Sink == nil {
+		return "logger"
+	} else {
+		return *c.Sink
+	}
+}
+
+func (c *auditConfig) GetTable() string {
+	if c.Table == nil {
+		return "audit_records"
+	} else {
+		return *c.Table
+	}
+}
+
+func (c *auditConfig) GetSubject() string {
+	if c.Subject == nil {
+		return "audit.records"
+	} else {
+		return *c.Subject
+	}
+}
+
+func (c *auditConfig) GetBufferSize() int {
+	if c.BufferSize == nil {
+		return 1024
+	} else {
+		return *c.BufferSize
+	}
+}
+
+func (c *auditConfig) GetBatchSize() int {
+	if c.BatchSize == nil {
+		return 100
+	} else {
+		return *c.BatchSize
+	}
+}
+
+func (c *auditConfig) GetFlushInterval() time.Duration {
+	if c.FlushInterval == nil {
+		return time.Second
+	} else {
+		return *c.FlushInterval
+	}
+}
+
+func (c *auditConfig) GetOverflow() string {
+	if c.Overflow == nil {
+		return "drop"
+	} else {
+		return *c.Overflow
+	}
+}
+
+func (c *auditConfig) GetBlockTimeout() time.Duration {
+	if c.BlockTimeout == nil {
+		return 100 * time.Millisecond
+	} else {
+		return *c.BlockTimeout
+	}
+}
+
+func (c *auditConfig) GetMaxRequestSize() int {
+	if c.MaxRequestSize == nil {
+		return 1024
+	} else {
+		return *c.MaxRequestSize
+	}
+}
+
+func (c *auditConfig) GetRedactFields() []string {
+	if c.RedactFields == nil {
+		return nil
+	} else {
+		return *c.RedactFields
+	}
+}
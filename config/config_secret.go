@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// secretPattern matches the explicit forms of secrets, other "$" in values are kept as is.
+var secretPattern = regexp.MustCompile(`\$\{(env|file):([^}]+)\}`)
+
+// expandSecret interpolates secrets in the given value.
+// The form ${env:NAME} is replaced with the value of environment variable NAME,
+// and the form ${file:PATH} is replaced with the trimmed content of the file at PATH.
+func expandSecret(value string) (string, error) {
+	var err error
+	expanded := secretPattern.ReplaceAllStringFunc(value, func(match string) string {
+		groups := secretPattern.FindStringSubmatch(match)
+		if groups[1] == "env" {
+			return os.Getenv(groups[2])
+		}
+		body, rerr := os.ReadFile(groups[2])
+		if rerr != nil {
+			if err == nil {
+				err = fmt.Errorf("failed to read secret file: %w", rerr)
+			}
+			return match
+		}
+		return strings.TrimSpace(string(body))
+	})
+	return expanded, err
+}
+
+// expandSecrets interpolates secrets in the loaded config, such as headers of exporters.
+func (c *rootConfig) expandSecrets() error {
+	var exporters []*exporterConfig
+	if c.Logging != nil && c.Logging.Exporter != nil {
+		exporters = append(exporters, &c.Logging.Exporter.exporterConfig)
+	}
+	if c.Trace != nil && c.Trace.Exporter != nil {
+		exporters = append(exporters, &c.Trace.Exporter.exporterConfig)
+	}
+	if c.Metric != nil && c.Metric.Exporter != nil {
+		exporters = append(exporters, &c.Metric.Exporter.exporterConfig)
+	}
+	for _, e := range exporters {
+		for k, v := range e.Headers {
+			expanded, err := expandSecret(v)
+			if err != nil {
+				return fmt.Errorf("invalid exporter header %s: %w", k, err)
+			}
+			e.Headers[k] = expanded
+		}
+	}
+	return nil
+}
@@ -21,6 +21,8 @@ func LoadYamlConfig() (RootConfig, error) {
 	cfg := &rootConfig{}
 	if err := yaml.Unmarshal(txt, cfg); err != nil {
 		return nil, err
+	} else if err := cfg.expandSecrets(); err != nil {
+		return nil, err
 	} else {
 		return cfg, nil
 	}
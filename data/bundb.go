@@ -15,6 +15,7 @@ import (
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/extra/bunotel"
 	"github.com/uptrace/bun/schema"
+	"github.com/uptrace/opentelemetry-go-extra/otelsql"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
@@ -74,9 +75,11 @@ func NewBunDB(cfg config.ServerDBConfig, logger logging.Logger, tp trace.TracerP
 	if err != nil {
 		return nil, err
 	}
+	// report connection pool stats, such as open, in-use and idle connections, and wait count
+	otelsql.ReportDBStatsMetrics(sdb, otelsql.WithMeterProvider(mp), otelsql.WithDBSystem(dialect.Name().String()))
 	bdb := bun.NewDB(sdb, dialect, bun.WithDiscardUnknownColumns())
 	bdb.AddQueryHook(bunotel.NewQueryHook(bunotel.WithTracerProvider(tp), bunotel.WithMeterProvider(mp)))
-	bdb.AddQueryHook(&globalQueryHook{logger: logger})
+	bdb.AddQueryHook(&globalQueryHook{logger: logging.Named(logger, "data.bun")})
 	bdb.RegisterModel()
 	return bdb, nil
 }
@@ -0,0 +1,253 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/schema"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/choral-io/gommerce-server-core/secure"
+)
+
+// HistoryTracked is implemented by models opting in to change history.
+type HistoryTracked interface {
+	// HistoryEntity returns the entity name that changes of the model are recorded with, such as "order".
+	HistoryEntity() string
+}
+
+// History is a change of an entity, recorded by EntityHistory.
+type History struct {
+	bun.BaseModel `bun:"table:entity_histories" json:"-"`
+
+	ID        int64             `bun:"id,pk,autoincrement" json:"id"`
+	Time      time.Time         `bun:"time,notnull" json:"time"`
+	Entity    string            `bun:"entity,notnull" json:"entity"`
+	EntityID  string            `bun:"entity_id,notnull" json:"entity_id"` // primary key values joined by comma
+	Operation string            `bun:"operation,notnull" json:"operation"` // INSERT, UPDATE or DELETE
+	Changes   map[string]Change `bun:"changes" json:"changes"`             // changed columns by column name
+	Subject   string            `bun:"subject" json:"subject,omitempty"`
+	TraceID   string            `bun:"trace_id" json:"trace_id,omitempty"`
+}
+
+// Change is the values of a column before and after a change, Old is nil for inserts and New is nil for deletes.
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// EntityHistory is a bun.QueryHook that records changes of models implementing HistoryTracked into a history table.
+// Only queries with a single struct model are recorded, such as db.NewUpdate().Model(order).WherePK(),
+// bulk queries with slice models or without models are not recorded.
+// Rows are read and changes are written with the connection of the query, so they take part in its transaction.
+// It is registered with (*bun.DB).AddQueryHook.
+type EntityHistory struct {
+	table string
+}
+
+// NewEntityHistory returns a new EntityHistory recording changes into the given table.
+func NewEntityHistory(table string) *EntityHistory {
+	return &EntityHistory{table: table}
+}
+
+// CreateTable creates the history table if it does not exist.
+func (h *EntityHistory) CreateTable(ctx context.Context, db bun.IDB) error {
+	_, err := db.NewCreateTable().Model((*History)(nil)).ModelTableExpr(h.table).IfNotExists().Exec(ctx)
+	return err
+}
+
+// Timeline returns changes of the given entity ordered by time, modifiers such as WithPaging are applied to the query.
+func (h *EntityHistory) Timeline(ctx context.Context, db bun.IDB, entity string, id string, mods ...func(*bun.SelectQuery) *bun.SelectQuery) ([]*History, error) {
+	var histories []*History
+	err := db.NewSelect().Model(&histories).ModelTableExpr("? AS history", bun.Ident(h.table)).
+		Where("entity = ?", entity).Where("entity_id = ?", id).
+		OrderExpr("time ASC, id ASC").Apply(mods...).Scan(ctx)
+	return histories, err
+}
+
+type historyKey struct{}
+
+// historyEvent is the state of a tracked query carried from BeforeQuery to AfterQuery.
+type historyEvent struct {
+	before reflect.Value
+}
+
+func (h *EntityHistory) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	q, table, strct, ok := historyQuery(event)
+	if !ok {
+		return ctx
+	}
+	he := &historyEvent{}
+	switch q.(type) {
+	case *bun.UpdateQuery, *bun.DeleteQuery:
+		// the row is read before the query is executed, for the old values of changed columns
+		if before, err := selectByPK(ctx, event.DB, q.GetConn(), table, strct); err == nil {
+			he.before = before
+		}
+	}
+	return context.WithValue(ctx, historyKey{}, he)
+}
+
+func (h *EntityHistory) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	he, ok := ctx.Value(historyKey{}).(*historyEvent)
+	if !ok || event.Err != nil {
+		return
+	}
+	q, table, strct, ok := historyQuery(event)
+	if !ok {
+		return
+	}
+	if event.Result == nil {
+		return
+	}
+	if rows, err := event.Result.RowsAffected(); err == nil && rows == 0 {
+		return
+	}
+	var before, after reflect.Value
+	switch q.(type) {
+	case *bun.InsertQuery:
+		strct = withLastInsertID(event, table, strct)
+		after = strct
+	case *bun.UpdateQuery:
+		if !he.before.IsValid() {
+			return
+		}
+		before = he.before
+		// the row is read again as the query may update only some of the columns
+		if v, err := selectByPK(ctx, event.DB, q.GetConn(), table, strct); err == nil {
+			after = v
+		} else {
+			after = strct
+		}
+	case *bun.DeleteQuery:
+		if !he.before.IsValid() {
+			return
+		}
+		before = he.before
+	}
+	changes := diffColumns(event.DB.Formatter(), table, before, after)
+	if len(changes) == 0 {
+		return
+	}
+	history := &History{
+		Time:      time.Now(),
+		Entity:    event.Model.(bun.TableModel).Value().(HistoryTracked).HistoryEntity(),
+		EntityID:  entityID(table, strct),
+		Operation: event.Operation(),
+		Changes:   changes,
+	}
+	if subject, ok := secure.SubjectFromContext(ctx); ok {
+		history.Subject = subject
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		history.TraceID = sc.TraceID().String()
+	}
+	// errors are reported by the logging hook of the insert query
+	_, _ = event.DB.NewInsert().Conn(q.GetConn()).Model(history).ModelTableExpr(h.table).Exec(context.WithoutCancel(ctx))
+}
+
+// historyQuery returns the query, table and struct of the event if its model is a single struct implementing HistoryTracked.
+func historyQuery(event *bun.QueryEvent) (interface{ GetConn() bun.IConn }, *schema.Table, reflect.Value, bool) {
+	var q interface{ GetConn() bun.IConn }
+	switch iq := event.IQuery.(type) {
+	case *bun.InsertQuery:
+		q = iq
+	case *bun.UpdateQuery:
+		q = iq
+	case *bun.DeleteQuery:
+		q = iq
+	default:
+		return nil, nil, reflect.Value{}, false
+	}
+	model, ok := event.Model.(bun.TableModel)
+	if !ok || model.Value() == nil {
+		return nil, nil, reflect.Value{}, false
+	}
+	if _, ok := model.Value().(HistoryTracked); !ok {
+		return nil, nil, reflect.Value{}, false
+	}
+	strct := reflect.ValueOf(model.Value())
+	for strct.Kind() == reflect.Pointer {
+		if strct.IsNil() {
+			return nil, nil, reflect.Value{}, false
+		}
+		strct = strct.Elem()
+	}
+	table := model.Table()
+	if strct.Kind() != reflect.Struct || len(table.PKs) == 0 {
+		return nil, nil, reflect.Value{}, false
+	}
+	return q, table, strct, true
+}
+
+// withLastInsertID returns a copy of strct with the autoincrement primary key set from the result of the insert query,
+// as bun sets it after query hooks for dialects without RETURNING or OUTPUT, such as mysql.
+// It returns strct if the key is set already or is not an autoincrement one.
+func withLastInsertID(event *bun.QueryEvent, table *schema.Table, strct reflect.Value) reflect.Value {
+	if event.DB.HasFeature(feature.Returning) || event.DB.HasFeature(feature.Output) ||
+		len(table.PKs) != 1 || !table.PKs[0].AutoIncrement || !table.PKs[0].HasZeroValue(strct) {
+		return strct
+	}
+	id, err := event.Result.LastInsertId()
+	if err != nil || id == 0 {
+		return strct
+	}
+	cp := reflect.New(table.Type).Elem()
+	cp.Set(strct)
+	if err := table.PKs[0].ScanValue(cp, id); err != nil {
+		return strct
+	}
+	return cp
+}
+
+// selectByPK reads the row with the same primary key values of strct into a new struct, with the connection of the query.
+func selectByPK(ctx context.Context, db *bun.DB, conn bun.IConn, table *schema.Table, strct reflect.Value) (reflect.Value, error) {
+	ptr := reflect.New(table.Type)
+	for _, f := range table.PKs {
+		f.Value(ptr.Elem()).Set(f.Value(strct))
+	}
+	if err := db.NewSelect().Conn(conn).Model(ptr.Interface()).WherePK().Scan(ctx); err != nil {
+		return reflect.Value{}, err
+	}
+	return ptr.Elem(), nil
+}
+
+// diffColumns returns changed columns between before and after, either of which may be invalid.
+// Values are compared by their sql representations, so values such as decimals with different exponents are equal.
+func diffColumns(fmter schema.Formatter, table *schema.Table, before, after reflect.Value) map[string]Change {
+	changes := make(map[string]Change)
+	for _, f := range table.Fields {
+		oldSQL, oldValue := columnValue(fmter, f, before)
+		newSQL, newValue := columnValue(fmter, f, after)
+		if !bytes.Equal(oldSQL, newSQL) {
+			changes[f.Name] = Change{Old: oldValue, New: newValue}
+		}
+	}
+	return changes
+}
+
+// columnValue returns the sql representation and the value of the column, or nil if strct is invalid or the column is null.
+func columnValue(fmter schema.Formatter, f *schema.Field, strct reflect.Value) ([]byte, any) {
+	if !strct.IsValid() {
+		return nil, nil
+	}
+	b := f.AppendValue(fmter, nil, strct)
+	if string(b) == "NULL" {
+		return nil, nil
+	}
+	return b, f.Value(strct).Interface()
+}
+
+func entityID(table *schema.Table, strct reflect.Value) string {
+	ids := make([]string, 0, len(table.PKs))
+	for _, f := range table.PKs {
+		ids = append(ids, fmt.Sprint(f.Value(strct).Interface()))
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,216 @@
+// Package errors provides typed application errors, which are mapped to grpc status with error details,
+// http status in the gateway, and logged with stack traces.
+//
+// Errors are usually declared as sentinels and created with a fresh stack trace where they occur:
+//
+//	var ErrOrderNotFound = errors.New(codes.NotFound, "ORDER_NOT_FOUND", "order not found").WithDomain("order.gommerce.io")
+//
+//	return nil, ErrOrderNotFound.New().WithMetadata("order_id", id)
+//
+// Errors with the same code and reason are matched by errors.Is of the standard library.
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"maps"
+	"runtime"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Error is an application error.
+type Error struct {
+	code       codes.Code
+	reason     string            // reason of ErrorInfo, in UPPER_SNAKE_CASE
+	domain     string            // domain of ErrorInfo, such as the service name
+	message    string            // developer-facing message, returned as the message of status
+	metadata   map[string]string // metadata of ErrorInfo
+	locale     string            // locale of the localized message
+	localized  string            // user-facing localized message, returned as LocalizedMessage if not empty
+	retryDelay time.Duration     // the error is retryable after the delay if positive, returned as RetryInfo
+	cause      error
+	stack      []uintptr
+}
+
+// New returns a new Error with the given code, reason and message, the stack trace is captured.
+func New(code codes.Code, reason, message string) *Error {
+	return &Error{code: code, reason: reason, message: message, stack: callers()}
+}
+
+// Newf returns a new Error with the given code, reason and formatted message, the stack trace is captured.
+func Newf(code codes.Code, reason, format string, args ...any) *Error {
+	return &Error{code: code, reason: reason, message: fmt.Sprintf(format, args...), stack: callers()}
+}
+
+// Wrap returns a new Error caused by the given error, the stack trace is captured.
+func Wrap(cause error, code codes.Code, reason, message string) *Error {
+	return &Error{code: code, reason: reason, message: message, cause: cause, stack: callers()}
+}
+
+// As finds the first Error in the chain of err.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// Code returns the grpc code of err, codes.OK if err is nil, and codes.Unknown if err has no code.
+func Code(err error) codes.Code {
+	if e, ok := As(err); ok {
+		return e.code
+	}
+	return status.Code(err)
+}
+
+func callers() []uintptr {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	return pcs[:n]
+}
+
+func (e *Error) clone() *Error {
+	c := *e
+	c.metadata = maps.Clone(e.metadata)
+	return &c
+}
+
+// New returns a copy of e with a fresh stack trace, used to return sentinel errors.
+func (e *Error) New() *Error {
+	c := e.clone()
+	c.stack = callers()
+	return c
+}
+
+// WithDomain returns a copy of e with the given domain.
+func (e *Error) WithDomain(domain string) *Error {
+	c := e.clone()
+	c.domain = domain
+	return c
+}
+
+// WithMetadata returns a copy of e with the given metadata added.
+func (e *Error) WithMetadata(key, value string) *Error {
+	c := e.clone()
+	if c.metadata == nil {
+		c.metadata = map[string]string{}
+	}
+	c.metadata[key] = value
+	return c
+}
+
+// WithLocalizedMessage returns a copy of e with the given user-facing message in the given locale, such as "en-US".
+func (e *Error) WithLocalizedMessage(locale, message string) *Error {
+	c := e.clone()
+	c.locale, c.localized = locale, message
+	return c
+}
+
+// WithRetryDelay returns a copy of e, which is retryable after the given delay.
+func (e *Error) WithRetryDelay(delay time.Duration) *Error {
+	c := e.clone()
+	c.retryDelay = delay
+	return c
+}
+
+// WithCause returns a copy of e caused by the given error.
+func (e *Error) WithCause(cause error) *Error {
+	c := e.clone()
+	c.cause = cause
+	return c
+}
+
+func (e *Error) Code() codes.Code {
+	return e.code
+}
+
+func (e *Error) Reason() string {
+	return e.reason
+}
+
+func (e *Error) Domain() string {
+	return e.domain
+}
+
+func (e *Error) Message() string {
+	return e.message
+}
+
+func (e *Error) Metadata() map[string]string {
+	return maps.Clone(e.metadata)
+}
+
+// LocalizedMessage returns the user-facing message and its locale.
+func (e *Error) LocalizedMessage() (locale, message string) {
+	return e.locale, e.localized
+}
+
+func (e *Error) Retryable() bool {
+	return e.retryDelay > 0
+}
+
+func (e *Error) RetryDelay() time.Duration {
+	return e.retryDelay
+}
+
+func (e *Error) Error() string {
+	msg := e.message
+	if e.reason != "" {
+		msg = e.reason + ": " + msg
+	}
+	if e.cause != nil {
+		msg = fmt.Sprintf("%s | caused by: %v", msg, e.cause)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an Error with the same code and reason.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.code == e.code && t.reason == e.reason
+}
+
+// GRPCStatus returns the grpc status for the error, with ErrorInfo, RetryInfo and LocalizedMessage details.
+// Implements the GRPCStatus() method, see status.FromError(error).
+// The cause is not included, it may contain internal details,
+// but grpc uses the message of the whole chain if e is wrapped by another error, so return e unwrapped from handlers.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.code, e.message)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: e.reason, Domain: e.domain, Metadata: e.metadata}}
+	if e.retryDelay > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.retryDelay)})
+	}
+	if e.localized != "" {
+		details = append(details, &errdetails.LocalizedMessage{Locale: e.locale, Message: e.localized})
+	}
+	if ds, err := st.WithDetails(details...); err == nil {
+		return ds
+	}
+	return st
+}
+
+// StackTrace returns the stack trace captured when the error was created.
+func (e *Error) StackTrace() string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
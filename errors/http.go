@@ -0,0 +1,10 @@
+package errors
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// HTTPStatus returns the http status for err, mapped from its grpc code like the gateway does.
+func HTTPStatus(err error) int {
+	return runtime.HTTPStatusFromCode(Code(err))
+}
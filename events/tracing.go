@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// headerCarrier adapts nats.Header to propagation.TextMapCarrier.
+type headerCarrier nats.Header
+
+var _ propagation.TextMapCarrier = headerCarrier(nil)
+
+func (c headerCarrier) Get(key string) string {
+	return nats.Header(c).Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	nats.Header(c).Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// InjectTraceContext injects the trace context of ctx into the headers of msg with the global TextMapPropagator.
+func InjectTraceContext(ctx context.Context, msg *nats.Msg) {
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(msg.Header))
+}
+
+// ExtractTraceContext returns a copy of ctx with the trace context extracted from the headers of msg.
+func ExtractTraceContext(ctx context.Context, msg *nats.Msg) context.Context {
+	if msg.Header == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Header))
+}
@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/zap"
+)
+
+type contextArgsKey struct{}
+
+// ContextWith returns a copy of ctx carrying args, which are added to every record logged with the returned context.
+// The args are appended to the ones already carried by ctx, and take the same form as Logger.With.
+func ContextWith(ctx context.Context, args ...any) context.Context {
+	prev := contextArgs(ctx)
+	merged := make([]any, 0, len(prev)+len(args))
+	merged = append(merged, prev...)
+	merged = append(merged, args...)
+	return context.WithValue(ctx, contextArgsKey{}, merged)
+}
+
+func contextArgs(ctx context.Context) []any {
+	if ctx == nil {
+		return nil
+	}
+	args, _ := ctx.Value(contextArgsKey{}).([]any)
+	return args
+}
+
+func extractContextAttrs(ctx context.Context) []slog.Attr {
+	args := contextArgs(ctx)
+	if len(args) == 0 {
+		return nil
+	}
+	var attrs []slog.Attr
+	for len(args) > 0 {
+		switch x := args[0].(type) {
+		case string:
+			if len(args) == 1 {
+				attrs = append(attrs, slog.String(badKey, x))
+				args = nil
+				continue
+			}
+			attrs = append(attrs, slog.Any(x, args[1]))
+			args = args[2:]
+		case slog.Attr:
+			attrs = append(attrs, x)
+			args = args[1:]
+		default:
+			attrs = append(attrs, slog.Any(badKey, x))
+			args = args[1:]
+		}
+	}
+	return attrs
+}
+
+func extractContextFields(ctx context.Context) []zap.Field {
+	if args := contextArgs(ctx); len(args) > 0 {
+		return argsToFields(args)
+	}
+	return nil
+}
+
+// ContextAttrs returns attributes derived from ctx, which are added to records logged with ctx,
+// including the trace context and the args carried by ContextWith.
+// It is useful for Logger implementations outside this package.
+func ContextAttrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	return append(extractTracingAttrs(ctx), extractContextAttrs(ctx)...)
+}
@@ -1,14 +1,17 @@
 package logging
 
 import (
+	"context"
 	"log/slog"
 
 	"go.uber.org/fx/fxevent"
 	"go.uber.org/zap/zapcore"
 )
 
-// NewFxeventLogger returns a new fxevent.Logger that logs to logger.
+// NewFxeventLogger returns a new fxevent.Logger that logs to logger, named "fx".
+// Loggers other than SlogLogger and ZapLogger are adapted to slog.
 func NewFxeventLogger(logger Logger, eventLevel Level, errorLevel Level) fxevent.Logger {
+	logger = Named(logger, "fx")
 	// check if logger is *SlogLogger or *ZapLogger
 	if s, ok := logger.(*SlogLogger); ok {
 		l := &fxevent.SlogLogger{
@@ -26,5 +29,51 @@ func NewFxeventLogger(logger Logger, eventLevel Level, errorLevel Level) fxevent
 		l.UseErrorLevel(zapcore.Level(errorLevel / 4))
 		return l
 	}
-	return &fxevent.ConsoleLogger{}
+	l := &fxevent.SlogLogger{
+		Logger: slog.New(&loggerSlogHandler{logger: logger}),
+	}
+	l.UseLogLevel(slog.Level(eventLevel))
+	l.UseErrorLevel(slog.Level(errorLevel))
+	return l
+}
+
+// loggerSlogHandler is a slog.Handler that logs records to a Logger.
+type loggerSlogHandler struct {
+	logger Logger
+	group  string // prefix of attribute keys
+}
+
+var _ slog.Handler = (*loggerSlogHandler)(nil)
+
+func (h *loggerSlogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *loggerSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	args := make([]any, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		args = append(args, h.prefixed(attr))
+		return true
+	})
+	h.logger.Log(ctx, Level(record.Level), record.Message, args...)
+	return nil
+}
+
+func (h *loggerSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	args := make([]any, len(attrs))
+	for i, attr := range attrs {
+		args[i] = h.prefixed(attr)
+	}
+	return &loggerSlogHandler{logger: h.logger.With(args...), group: h.group}
+}
+
+func (h *loggerSlogHandler) WithGroup(name string) slog.Handler {
+	return &loggerSlogHandler{logger: h.logger, group: h.group + name + "."}
+}
+
+func (h *loggerSlogHandler) prefixed(attr slog.Attr) slog.Attr {
+	if h.group != "" {
+		attr.Key = h.group + attr.Key
+	}
+	return attr
 }
@@ -2,12 +2,14 @@ package logging
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 func errorToCode(err error) codes.Code {
@@ -22,25 +24,65 @@ func errorToCode(err error) codes.Code {
 	return status.Code(err)
 }
 
+// errorToFields extracts the stack trace of errors carrying one, such as errors of the errors package.
+func errorToFields(err error) logging.Fields {
+	var st interface{ StackTrace() string }
+	if errors.As(err, &st) {
+		return logging.Fields{"grpc.error.stack", st.StackTrace()}
+	}
+	return nil
+}
+
 // GRPCLogger provides a grpc middleware that logs grpc calls.
 type GRPCLogger struct {
-	logger Logger
-	opts   []logging.Option
+	logger  Logger
+	opts    []logging.Option
+	payload *payloadRenderer // payloads are logged if not nil
 }
 
-// NewGRPCLogger creates a new GRPCLogger with logger.
-func NewGRPCLogger(logger Logger) *GRPCLogger {
-	return &GRPCLogger{
-		logger: logger,
-		opts: []logging.Option{
-			logging.WithLogOnEvents(logging.StartCall, logging.FinishCall), // logging.PayloadReceived, logging.PayloadSent
-			logging.WithCodes(errorToCode),
-		},
+// GRPCLoggerOption is an option for GRPCLogger, used to configure it.
+type GRPCLoggerOption func(*GRPCLogger)
+
+// NewGRPCLogger creates a new GRPCLogger with logger, named "grpc".
+func NewGRPCLogger(logger Logger, opts ...GRPCLoggerOption) *GRPCLogger {
+	l := &GRPCLogger{logger: Named(logger, "grpc")}
+	for _, opt := range opts {
+		opt(l)
 	}
+	events := []logging.LoggableEvent{logging.StartCall, logging.FinishCall}
+	if l.payload != nil {
+		events = append(events, logging.PayloadReceived, logging.PayloadSent)
+	}
+	l.opts = []logging.Option{
+		logging.WithLogOnEvents(events...),
+		logging.WithCodes(errorToCode),
+		logging.WithErrorFields(errorToFields),
+	}
+	return l
 }
 
 // Log implements logging.Logger.
+// Payloads are only logged for the configured methods, rendered as json with sensitive fields redacted.
 func (l *GRPCLogger) Log(ctx context.Context, level logging.Level, msg string, fields ...any) {
+	if l.payload != nil {
+		var service, method string
+		for i := 0; i+1 < len(fields); i += 2 {
+			switch fields[i] {
+			case logging.ServiceFieldKey:
+				service, _ = fields[i+1].(string)
+			case logging.MethodFieldKey:
+				method, _ = fields[i+1].(string)
+			case "grpc.request.content", "grpc.response.content":
+				if !l.payload.match("/" + service + "/" + method) {
+					return
+				}
+				if m, ok := fields[i+1].(proto.Message); ok {
+					fields = append([]any(nil), fields...) // do not modify fields owned by the caller
+					fields[i+1] = l.payload.render(m)
+				}
+			}
+		}
+	}
 	l.logger.Log(ctx, Level(level), msg, fields...)
 }
 
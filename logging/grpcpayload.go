@@ -0,0 +1,117 @@
+package logging
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+const redactedValue = "[REDACTED]"
+
+// payloadRenderer renders payloads of grpc calls as json, with sensitive fields redacted.
+type payloadRenderer struct {
+	methods      []string // method patterns without the leading slash
+	redactFields []string // field paths matched against the end of field paths
+	maxSize      int
+}
+
+// WithPayloadLogging returns a GRPCLoggerOption that logs request and response payloads of the configured methods.
+// Fields marked with the debug_redact option or matched by the configured field paths are redacted,
+// a field path matches the end of field paths, so "password" matches "user.password" as well.
+func WithPayloadLogging(cfg config.LoggingPayloadConfig) GRPCLoggerOption {
+	return func(l *GRPCLogger) {
+		methods := cfg.GetMethods()
+		if len(methods) == 0 {
+			return
+		}
+		r := &payloadRenderer{redactFields: cfg.GetRedactFields(), maxSize: cfg.GetMaxSize()}
+		for _, method := range methods {
+			r.methods = append(r.methods, strings.TrimPrefix(method, "/"))
+		}
+		l.payload = r
+	}
+}
+
+// RenderProtoMessage renders the given message as json like payload logging does,
+// with fields marked with the debug_redact option or matched by the given field paths redacted,
+// and truncated to maxSize bytes if maxSize is positive.
+func RenderProtoMessage(msg proto.Message, maxSize int, redactFields ...string) string {
+	r := &payloadRenderer{redactFields: redactFields, maxSize: maxSize}
+	return r.render(msg)
+}
+
+// match reports whether payloads of the given full method are logged.
+func (r *payloadRenderer) match(method string) bool {
+	method = strings.TrimPrefix(method, "/")
+	for _, pattern := range r.methods {
+		if ok, _ := path.Match(pattern, method); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// render renders the given message as json, with sensitive fields redacted and large payloads truncated.
+func (r *payloadRenderer) render(msg proto.Message) string {
+	msg = proto.Clone(msg)
+	r.redact(msg.ProtoReflect(), "")
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return fmt.Sprintf("!ERROR: %v", err)
+	}
+	if r.maxSize > 0 && len(b) > r.maxSize {
+		return fmt.Sprintf("%s...(%d bytes truncated)", b[:r.maxSize], len(b)-r.maxSize)
+	}
+	return string(b)
+}
+
+func (r *payloadRenderer) redact(m protoreflect.Message, prefix string) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		if r.sensitive(fd, name) {
+			if fd.Kind() == protoreflect.StringKind && fd.Cardinality() != protoreflect.Repeated {
+				m.Set(fd, protoreflect.ValueOfString(redactedValue))
+			} else {
+				m.Clear(fd)
+			}
+			return true
+		}
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				r.redact(list.Get(i).Message(), name)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				r.redact(mv.Message(), name)
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			r.redact(v.Message(), name)
+		}
+		return true
+	})
+}
+
+func (r *payloadRenderer) sensitive(fd protoreflect.FieldDescriptor, name string) bool {
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDebugRedact() {
+		return true
+	}
+	for _, field := range r.redactFields {
+		if name == field || strings.HasSuffix(name, "."+field) {
+			return true
+		}
+	}
+	return false
+}
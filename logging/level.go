@@ -0,0 +1,150 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// The level shared by SlogLogger and ZapLogger, it can be changed at runtime with SetLevel.
+// Handlers and cores are enabled at the minimum of the level and all overrides,
+// the precise level of each named logger is checked by wrappedSlogHandler and namedLevelZapCore.
+var (
+	slogLevel    = new(slog.LevelVar)
+	slogMinLevel = new(slog.LevelVar)
+	zapMinLevel  = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+	levelOverrides atomic.Pointer[[]levelOverride] // sorted by prefix length in descending order
+
+	levelMu     sync.Mutex
+	levelBase   Level       // the level to revert to when the temporary level expires
+	levelTimer  *time.Timer // the timer reverting the temporary level, nil if no temporary level is set
+	levelExpiry time.Time   // the time the temporary level expires
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelPanic:
+		return "PANIC"
+	case LevelFatal:
+		return "FATAL"
+	}
+	return slog.Level(l).String()
+}
+
+// ParseLevel parses a level name, such as "debug" or "WARN".
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "panic":
+		return LevelPanic, nil
+	case "fatal":
+		return LevelFatal, nil
+	}
+	return 0, fmt.Errorf("unknown logging level: %s", name)
+}
+
+// levelOverride overrides the level of loggers whose name is prefix or starts with prefix followed by a dot.
+type levelOverride struct {
+	prefix string
+	level  Level
+}
+
+func storeLevel(level Level) {
+	slogLevel.Set(slog.Level(level))
+	minLevel := level
+	if overrides := levelOverrides.Load(); overrides != nil {
+		for _, o := range *overrides {
+			minLevel = min(minLevel, o.level)
+		}
+	}
+	slogMinLevel.Set(slog.Level(minLevel))
+	zapMinLevel.SetLevel(zapcore.Level(minLevel / 4))
+}
+
+// SetLevelOverrides sets the levels of named loggers by name prefix, such as {"data.bun": LevelWarn, "secure": LevelDebug}.
+// The longest matched prefix wins, loggers not matched by any prefix use the level set by SetLevel.
+func SetLevelOverrides(levels map[string]Level) {
+	overrides := make([]levelOverride, 0, len(levels))
+	for prefix, level := range levels {
+		overrides = append(overrides, levelOverride{prefix: prefix, level: level})
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		return len(overrides[i].prefix) > len(overrides[j].prefix)
+	})
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	levelOverrides.Store(&overrides)
+	storeLevel(GetLevel())
+}
+
+// GetLoggerLevel returns the level of the logger with the given name.
+func GetLoggerLevel(name string) Level {
+	if overrides := levelOverrides.Load(); overrides != nil && name != "" {
+		for _, o := range *overrides {
+			if name == o.prefix || (strings.HasPrefix(name, o.prefix) && name[len(o.prefix)] == '.') {
+				return o.level
+			}
+		}
+	}
+	return GetLevel()
+}
+
+// GetLevel returns the current logging level.
+func GetLevel() Level {
+	return Level(slogLevel.Level())
+}
+
+// GetLevelExpiry returns the time the temporary logging level expires, or zero time if no temporary level is set.
+func GetLevelExpiry() time.Time {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	return levelExpiry
+}
+
+// SetLevel sets the logging level, and cancels the temporary level if any.
+func SetLevel(level Level) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	if levelTimer != nil {
+		levelTimer.Stop()
+		levelTimer, levelExpiry = nil, time.Time{}
+	}
+	levelBase = level
+	storeLevel(level)
+}
+
+// SetLevelTemporarily sets the logging level, and reverts it after ttl.
+// If a temporary level is already set, it is replaced and the level is still reverted to the one before it.
+func SetLevelTemporarily(level Level, ttl time.Duration) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	if levelTimer != nil {
+		levelTimer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		levelMu.Lock()
+		defer levelMu.Unlock()
+		if levelTimer == timer {
+			levelTimer, levelExpiry = nil, time.Time{}
+			storeLevel(levelBase)
+		}
+	})
+	levelTimer, levelExpiry = timer, time.Now().Add(ttl)
+	storeLevel(level)
+}
@@ -35,3 +35,20 @@ type Logger interface {
 	// Fatal logs a message at level Fatal.
 	Fatal(ctx context.Context, message string, args ...any)
 }
+
+// NamedLogger is implemented by loggers supporting names, such as the loggers of this package.
+type NamedLogger interface {
+	Logger
+	// Named returns a new Logger with name appended to the logger's name, separated by a dot.
+	// The level of named loggers can be overridden by name prefix, see SetLevelOverrides.
+	Named(name string) Logger
+}
+
+// Named returns the given logger with name appended to its name if it implements NamedLogger,
+// or the logger unchanged otherwise.
+func Named(logger Logger, name string) Logger {
+	if nl, ok := logger.(NamedLogger); ok {
+		return nl.Named(name)
+	}
+	return logger
+}
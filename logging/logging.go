@@ -4,13 +4,45 @@ import (
 	"log/slog"
 	"sync/atomic"
 
+	"go.opentelemetry.io/otel/log"
+
 	"github.com/choral-io/gommerce-server-core/config"
 )
 
+// instrumentationName is the instrumentation scope name of bridged opentelemetry loggers.
+const instrumentationName = "github.com/choral-io/gommerce-server-core/logging"
+
 type loggerWrapper struct {
 	Logger
 }
 
+// LoggerOption is an option for SlogLogger and ZapLogger, used to configure them.
+type LoggerOption func(*loggerOptions)
+
+type loggerOptions struct {
+	provider  log.LoggerProvider // opentelemetry logger provider, logs are bridged to it if not nil
+	exclusive bool               // whether logs are only sent to the opentelemetry logger provider
+	sinks     []Sink             // sinks replacing stderr if not empty
+	sampler   *logSampler        // records are sampled if not nil
+}
+
+func newLoggerOptions(opts []LoggerOption) *loggerOptions {
+	o := &loggerOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithLoggerProvider returns a LoggerOption that bridges logs to the given opentelemetry LoggerProvider.
+// If exclusive is true, logs are only sent to the LoggerProvider, instead of alongside stderr.
+func WithLoggerProvider(lp log.LoggerProvider, exclusive bool) LoggerOption {
+	return func(o *loggerOptions) {
+		o.provider = lp
+		o.exclusive = exclusive
+	}
+}
+
 var (
 	defaultLogger atomic.Value
 )
@@ -19,23 +51,49 @@ func init() {
 	defaultLogger.Store(loggerWrapper{Logger: &SlogLogger{logger: slog.Default()}})
 }
 
-// NewLogger creates a new Logger instance with the given config.
-func NewLogger(cfg config.LoggingConfig) (l Logger, err error) {
+// NewLoggerWithProvider creates a new Logger instance with the given config like NewLogger,
+// logs are bridged to the given LoggerProvider unless the logging exporter protocol is noop, lp may be nil.
+func NewLoggerWithProvider(cfg config.LoggingConfig, lp log.LoggerProvider) (Logger, error) {
+	if ecfg := cfg.GetExporterConfig(); lp != nil && ecfg.GetProtocol() != "noop" {
+		return NewLogger(cfg, WithLoggerProvider(lp, ecfg.GetExclusive()))
+	}
+	return NewLogger(cfg)
+}
+
+// NewLogger creates a new Logger instance with the given config and options.
+// Levels of named loggers are overridden by the configured levels, see SetLevelOverrides.
+func NewLogger(cfg config.LoggingConfig, opts ...LoggerOption) (l Logger, err error) {
+	opts = append([]LoggerOption(nil), opts...)
+	if sinks, err := NewSinks(cfg.GetSinks()); err != nil {
+		return nil, err
+	} else if len(sinks) > 0 {
+		opts = append(opts, WithSinks(sinks...))
+	}
+	if scfg := cfg.GetSamplingConfig(); scfg.GetEnabled() {
+		opts = append(opts, WithSampling(scfg.GetInterval(), scfg.GetFirst(), scfg.GetThereafter()))
+	}
 	zcfg := cfg.GetZapLogger()
 	if zcfg != nil {
-		if l, err = NewZapLogger(zcfg.GetPreset()); err != nil {
+		if l, err = NewZapLoggerWithConfig(zcfg, opts...); err != nil {
 			return nil, err
 		}
 	}
 	scfg := cfg.GetSlogLogger()
 	if scfg != nil {
-		if l, err = NewSlogLogger(scfg.GetHandler(), scfg.GetAddSource(), scfg.GetLeveler()); err != nil {
+		if l, err = NewSlogLogger(scfg.GetHandler(), scfg.GetAddSource(), scfg.GetLeveler(), opts...); err != nil {
 			return nil, err
 		}
 	}
 	if l == nil {
 		l = &SlogLogger{logger: slog.Default()}
 	}
+	levels := make(map[string]Level, len(cfg.GetLevels()))
+	for name, value := range cfg.GetLevels() {
+		if levels[name], err = ParseLevel(value); err != nil {
+			return nil, err
+		}
+	}
+	SetLevelOverrides(levels)
 	return
 }
 
@@ -0,0 +1,229 @@
+// Package logtest provides an in-memory logging.Logger for asserting what components logged in tests.
+//
+// A typical usage in a test looks like:
+//
+//	logs := logtest.Install(t) // installed as logging.DefaultLogger until the test finishes
+//	svc.DoSomething(ctx)
+//	if !logs.Contains(logging.LevelWarn, "payment declined") {
+//		t.Error("expected a warning")
+//	}
+package logtest
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/fx/fxevent"
+
+	"github.com/choral-io/gommerce-server-core/logging"
+)
+
+const badKey = "!BADKEY"
+
+// Entry is a record logged by Logger.
+type Entry struct {
+	Level   logging.Level
+	Logger  string          // name of the logger, see logging.Logger.Named
+	Message string          // the logged message
+	Args    []any           // args added by With followed by args of the call
+	Attrs   map[string]any  // args and context-derived attributes, such as trace.id and args of logging.ContextWith, values are normalized by slog, such as int to int64
+	Context context.Context // the context of the call, nil if not given
+}
+
+// Attr returns the value of the attribute with the given key.
+func (e Entry) Attr(key string) (any, bool) {
+	v, ok := e.Attrs[key]
+	return v, ok
+}
+
+// recorder holds entries shared by a Logger and loggers derived from it.
+type recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Logger is an in-memory logging.Logger, which records all entries regardless of level.
+// Panic panics after recording like other loggers, while Fatal only records the entry instead of exiting.
+type Logger struct {
+	rec  *recorder
+	name string
+	args []any
+}
+
+var _ logging.NamedLogger = (*Logger)(nil)
+
+// New creates a new Logger.
+func New() *Logger {
+	return &Logger{rec: &recorder{}}
+}
+
+// Install creates a new Logger and sets it as logging.DefaultLogger until the test finishes.
+func Install(tb testing.TB) *Logger {
+	tb.Helper()
+	l := New()
+	prev := logging.DefaultLogger()
+	logging.SetDefaultLogger(l)
+	tb.Cleanup(func() {
+		logging.SetDefaultLogger(prev)
+	})
+	return l
+}
+
+// FxeventLogger returns an fxevent.Logger recording fx events to l, used with fx.WithLogger.
+func (l *Logger) FxeventLogger() fxevent.Logger {
+	return logging.NewFxeventLogger(l, logging.LevelInfo, logging.LevelError)
+}
+
+func (l *Logger) With(args ...any) logging.Logger {
+	merged := make([]any, 0, len(l.args)+len(args))
+	merged = append(merged, l.args...)
+	merged = append(merged, args...)
+	return &Logger{rec: l.rec, name: l.name, args: merged}
+}
+
+func (l *Logger) Named(name string) logging.Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+	return &Logger{rec: l.rec, name: name, args: l.args}
+}
+
+func (l *Logger) Log(ctx context.Context, level logging.Level, message string, args ...any) {
+	entry := Entry{
+		Level:   level,
+		Logger:  l.name,
+		Message: message,
+		Args:    append(append(make([]any, 0, len(l.args)+len(args)), l.args...), args...),
+		Attrs:   map[string]any{},
+		Context: ctx,
+	}
+	addAttrs(entry.Attrs, entry.Args)
+	for _, attr := range logging.ContextAttrs(ctx) {
+		entry.Attrs[attr.Key] = attrValue(attr.Value)
+	}
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	l.rec.entries = append(l.rec.entries, entry)
+}
+
+func (l *Logger) Debug(ctx context.Context, message string, args ...any) {
+	l.Log(ctx, logging.LevelDebug, message, args...)
+}
+
+func (l *Logger) Info(ctx context.Context, message string, args ...any) {
+	l.Log(ctx, logging.LevelInfo, message, args...)
+}
+
+func (l *Logger) Warn(ctx context.Context, message string, args ...any) {
+	l.Log(ctx, logging.LevelWarn, message, args...)
+}
+
+func (l *Logger) Error(ctx context.Context, message string, args ...any) {
+	l.Log(ctx, logging.LevelError, message, args...)
+}
+
+func (l *Logger) Panic(ctx context.Context, message string, args ...any) {
+	l.Log(ctx, logging.LevelPanic, message, args...)
+	panic(message)
+}
+
+func (l *Logger) Fatal(ctx context.Context, message string, args ...any) {
+	l.Log(ctx, logging.LevelFatal, message, args...)
+}
+
+// Entries returns all recorded entries, including the ones of loggers derived from l.
+func (l *Logger) Entries() []Entry {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	return append([]Entry(nil), l.rec.entries...)
+}
+
+// Filter returns recorded entries matched by the given function.
+func (l *Logger) Filter(f func(Entry) bool) []Entry {
+	var entries []Entry
+	for _, e := range l.Entries() {
+		if f(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// FilterLevel returns recorded entries at or above the given level.
+func (l *Logger) FilterLevel(level logging.Level) []Entry {
+	return l.Filter(func(e Entry) bool { return e.Level >= level })
+}
+
+// FilterMessage returns recorded entries with the given message.
+func (l *Logger) FilterMessage(message string) []Entry {
+	return l.Filter(func(e Entry) bool { return e.Message == message })
+}
+
+// FilterMessageContains returns recorded entries whose message contains the given substring.
+func (l *Logger) FilterMessageContains(substr string) []Entry {
+	return l.Filter(func(e Entry) bool { return strings.Contains(e.Message, substr) })
+}
+
+// FilterLogger returns recorded entries of the logger with the given name, or the ones named under it.
+func (l *Logger) FilterLogger(name string) []Entry {
+	return l.Filter(func(e Entry) bool { return e.Logger == name || strings.HasPrefix(e.Logger, name+".") })
+}
+
+// FilterAttr returns recorded entries having the attribute with the given key and value,
+// values are normalized by slog and compared with reflect.DeepEqual, so FilterAttr("count", 5) matches int64(5).
+func (l *Logger) FilterAttr(key string, value any) []Entry {
+	value = attrValue(slog.AnyValue(value))
+	return l.Filter(func(e Entry) bool {
+		v, ok := e.Attrs[key]
+		return ok && reflect.DeepEqual(v, value)
+	})
+}
+
+// Contains reports whether an entry with the given level and message is recorded.
+func (l *Logger) Contains(level logging.Level, message string) bool {
+	return len(l.Filter(func(e Entry) bool { return e.Level == level && e.Message == message })) > 0
+}
+
+// Len returns the number of recorded entries.
+func (l *Logger) Len() int {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	return len(l.rec.entries)
+}
+
+// Reset removes all recorded entries.
+func (l *Logger) Reset() {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	l.rec.entries = nil
+}
+
+// attrValue returns the value of attributes recorded in entries, with LogValuers resolved.
+func attrValue(v slog.Value) any {
+	return v.Resolve().Any()
+}
+
+func addAttrs(attrs map[string]any, args []any) {
+	for len(args) > 0 {
+		switch x := args[0].(type) {
+		case string:
+			if len(args) == 1 {
+				attrs[badKey] = x
+				args = nil
+				continue
+			}
+			attrs[x] = attrValue(slog.AnyValue(args[1]))
+			args = args[2:]
+		case slog.Attr:
+			attrs[x.Key] = attrValue(x.Value)
+			args = args[1:]
+		default:
+			attrs[badKey] = x
+			args = args[1:]
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap/zapcore"
+)
+
+// sampleBuckets is the number of counters records are hashed into, like zapcore.NewSampler.
+const sampleBuckets = 4096
+
+// droppedRecords counts records dropped by sampling by level, it is exported by RegisterMetrics.
+var droppedRecords sync.Map // map[Level]*atomic.Int64
+
+func countDropped(level Level) {
+	counter, ok := droppedRecords.Load(level)
+	if !ok {
+		counter, _ = droppedRecords.LoadOrStore(level, new(atomic.Int64))
+	}
+	counter.(*atomic.Int64).Add(1)
+}
+
+// RegisterMetrics registers metrics of the logging package with the given MeterProvider.
+// The log.records.dropped counter reports records dropped by sampling, by level.
+// It is called by otel.NewMeterProvider, so it is only needed for meter providers created otherwise.
+func RegisterMetrics(mp metric.MeterProvider) error {
+	_, err := mp.Meter(instrumentationName).Int64ObservableCounter("log.records.dropped",
+		metric.WithUnit("{record}"),
+		metric.WithDescription("Number of log records dropped by sampling."),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			droppedRecords.Range(func(key, value any) bool {
+				o.Observe(value.(*atomic.Int64).Load(), metric.WithAttributes(attribute.String("log.level", key.(Level).String())))
+				return true
+			})
+			return nil
+		}),
+	)
+	return err
+}
+
+// WithSampling returns a LoggerOption that samples records with the same level and message.
+// The first records per interval are logged, then every thereafter-th record, all of them are dropped if thereafter is 0.
+func WithSampling(interval time.Duration, first, thereafter int) LoggerOption {
+	return func(o *loggerOptions) {
+		o.sampler = &logSampler{interval: interval, first: uint64(first), thereafter: uint64(thereafter)}
+	}
+}
+
+// logSampler decides whether records are sampled, by counting records with the same level and message per interval.
+type logSampler struct {
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+	counts     [sampleBuckets]sampleCounter
+}
+
+type sampleCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+func (s *logSampler) sample(t time.Time, level Level, message string) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte{byte(level)})
+	_, _ = h.Write([]byte(message))
+	c := &s.counts[h.Sum32()%sampleBuckets]
+	now := t.UnixNano()
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return s.allow(c.count.Add(1))
+	}
+	if !c.resetAt.CompareAndSwap(resetAt, now+s.interval.Nanoseconds()) {
+		// the window is reset by another record concurrently
+		return s.allow(c.count.Add(1))
+	}
+	c.count.Store(1)
+	return true
+}
+
+func (s *logSampler) allow(n uint64) bool {
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
+
+// samplingSlogHandler is a slog.Handler that drops records not sampled.
+type samplingSlogHandler struct {
+	innerHandler slog.Handler
+	sampler      *logSampler
+}
+
+var _ slog.Handler = (*samplingSlogHandler)(nil)
+
+func (h *samplingSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.innerHandler.Enabled(ctx, level)
+}
+
+func (h *samplingSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if !h.sampler.sample(record.Time, Level(record.Level), record.Message) {
+		countDropped(Level(record.Level))
+		return nil
+	}
+	return h.innerHandler.Handle(ctx, record)
+}
+
+func (h *samplingSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingSlogHandler{innerHandler: h.innerHandler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingSlogHandler) WithGroup(name string) slog.Handler {
+	return &samplingSlogHandler{innerHandler: h.innerHandler.WithGroup(name), sampler: h.sampler}
+}
+
+// newZapSamplingCore wraps core with zapcore sampler, which samples by level and message as well.
+func newZapSamplingCore(core zapcore.Core, s *logSampler) zapcore.Core {
+	return zapcore.NewSamplerWithOptions(core, s.interval, int(s.first), int(s.thereafter), zapcore.SamplerHook(zapSamplingHook))
+}
+
+// zapSamplingHook counts records dropped by zapcore sampler, including the one of the production preset.
+func zapSamplingHook(entry zapcore.Entry, dec zapcore.SamplingDecision) {
+	if dec&zapcore.LogDropped != 0 {
+		countDropped(Level(entry.Level * 4))
+	}
+}
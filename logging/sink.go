@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+// Sink is a destination of logs with its own format and minimum level.
+type Sink struct {
+	Writer io.Writer // the destination, it is synced after writes if it implements zapcore.WriteSyncer
+	Format string    // text or json
+	Level  Level     // the minimum level of records written to the sink
+}
+
+// NewSinks creates sinks with the given configs.
+// Supported sink types are: stderr, stdout and file, files are rotated by size and age.
+func NewSinks(cfgs []config.LoggingSinkConfig) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		var w io.Writer
+		switch cfg.GetType() {
+		case "stderr":
+			w = os.Stderr
+		case "stdout":
+			w = os.Stdout
+		case "file":
+			w = &lumberjack.Logger{
+				Filename:   cfg.GetPath(),
+				MaxSize:    cfg.GetMaxSize(),
+				MaxAge:     cfg.GetMaxAge(),
+				MaxBackups: cfg.GetMaxBackups(),
+				Compress:   cfg.GetCompress(),
+				LocalTime:  cfg.GetLocalTime(),
+			}
+		default:
+			return nil, fmt.Errorf("unknown logging sink type: %s", cfg.GetType())
+		}
+		if format := cfg.GetFormat(); format != "text" && format != "json" {
+			return nil, fmt.Errorf("unknown logging sink format: %s", format)
+		}
+		sinks = append(sinks, Sink{Writer: w, Format: cfg.GetFormat(), Level: Level(cfg.GetLevel())})
+	}
+	return sinks, nil
+}
+
+// WithSinks returns a LoggerOption that writes logs to the given sinks, instead of stderr.
+func WithSinks(sinks ...Sink) LoggerOption {
+	return func(o *loggerOptions) {
+		o.sinks = append(o.sinks, sinks...)
+	}
+}
+
+// newSlogSinkHandler creates a slog.Handler writing to the given sinks.
+func newSlogSinkHandler(sinks []Sink, options *slog.HandlerOptions) slog.Handler {
+	handlers := make(multiSlogHandler, len(sinks))
+	for i, sink := range sinks {
+		var h slog.Handler
+		if sink.Format == "json" {
+			h = slog.NewJSONHandler(sink.Writer, options)
+		} else {
+			h = slog.NewTextHandler(sink.Writer, options)
+		}
+		handlers[i] = &levelSlogHandler{innerHandler: h, level: slog.Level(sink.Level)}
+	}
+	if len(handlers) == 1 {
+		return handlers[0]
+	}
+	return handlers
+}
+
+// newZapSinkCore creates a zapcore.Core writing to the given sinks, with the given encoder config.
+func newZapSinkCore(sinks []Sink, ecfg zapcore.EncoderConfig, enabler zapcore.LevelEnabler) zapcore.Core {
+	cores := make([]zapcore.Core, len(sinks))
+	for i, sink := range sinks {
+		var enc zapcore.Encoder
+		if sink.Format == "json" {
+			enc = zapcore.NewJSONEncoder(ecfg)
+		} else {
+			enc = zapcore.NewConsoleEncoder(ecfg)
+		}
+		level := zapcore.Level(sink.Level / 4)
+		cores[i] = zapcore.NewCore(enc, zapcore.Lock(zapcore.AddSync(sink.Writer)), zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return l >= level && enabler.Enabled(l)
+		}))
+	}
+	return zapcore.NewTee(cores...)
+}
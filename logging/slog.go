@@ -7,38 +7,71 @@ import (
 	"os"
 	"runtime"
 	"time"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
 )
 
 // SlogLogger is a Logger implementation that uses slog.
 type SlogLogger struct {
 	logger *slog.Logger
+	name   string
 }
 
-var _ Logger = (*SlogLogger)(nil)
+var _ NamedLogger = (*SlogLogger)(nil)
 
-func NewSlogLogger(handler string, addSource bool, level slog.Leveler) (*SlogLogger, error) {
+// NewSlogLogger creates a new SlogLogger and sets it as the default slog logger.
+// The given level is set as the shared logging level, see SetLevel.
+// Logs are written to stderr with the given handler, or to the sinks given by WithSinks.
+func NewSlogLogger(handler string, addSource bool, level slog.Leveler, opts ...LoggerOption) (*SlogLogger, error) {
+	o := newLoggerOptions(opts)
 	options := &slog.HandlerOptions{
 		AddSource: addSource,
-		Level:     level,
+		Level:     slogMinLevel,
 	}
-	if handler == "text" {
-		slog.SetDefault(slog.New(&wrappedSlogHandler{
-			innerHandler: slog.NewTextHandler(os.Stderr, options),
-			extractAttrs: []func(context.Context) []slog.Attr{extractTracingAttrs},
-		}))
+	var inner slog.Handler
+	if len(o.sinks) > 0 {
+		inner = newSlogSinkHandler(o.sinks, options)
+	} else if handler == "text" {
+		inner = slog.NewTextHandler(os.Stderr, options)
 	} else if handler == "json" {
-		slog.SetDefault(slog.New(&wrappedSlogHandler{
-			innerHandler: slog.NewJSONHandler(os.Stderr, options),
-			extractAttrs: []func(context.Context) []slog.Attr{extractTracingAttrs},
-		}))
+		inner = slog.NewJSONHandler(os.Stderr, options)
 	} else {
 		return nil, errors.New("unknown logging handler")
 	}
+	if o.provider != nil {
+		bridge := &levelSlogHandler{
+			innerHandler: otelslog.NewHandler(instrumentationName, otelslog.WithLoggerProvider(o.provider), otelslog.WithSource(addSource)),
+			level:        slogMinLevel,
+		}
+		if o.exclusive {
+			inner = bridge
+		} else {
+			inner = multiSlogHandler{inner, bridge}
+		}
+	}
+	if o.sampler != nil {
+		inner = &samplingSlogHandler{innerHandler: inner, sampler: o.sampler}
+	}
+	SetLevel(Level(level.Level()))
+	slog.SetDefault(slog.New(&wrappedSlogHandler{
+		innerHandler: inner,
+		extractAttrs: []func(context.Context) []slog.Attr{extractTracingAttrs, extractContextAttrs},
+	}))
 	return &SlogLogger{logger: slog.Default()}, nil
 }
 
 func (l *SlogLogger) With(args ...any) Logger {
-	return &SlogLogger{logger: l.logger.With(args...)}
+	return &SlogLogger{logger: l.logger.With(args...), name: l.name}
+}
+
+func (l *SlogLogger) Named(name string) Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+	if h, ok := l.logger.Handler().(*wrappedSlogHandler); ok {
+		return &SlogLogger{logger: slog.New(h.withName(name)), name: name}
+	}
+	return &SlogLogger{logger: l.logger.With(slog.String("logger", name)), name: name}
 }
 
 func (l *SlogLogger) log(ctx context.Context, level Level, message string, args ...any) {
@@ -87,18 +120,27 @@ func (l *SlogLogger) Fatal(ctx context.Context, message string, args ...any) {
 	os.Exit(1)
 }
 
+// wrappedSlogHandler adds extracted attrs to records, and checks the precise level of the named logger.
 type wrappedSlogHandler struct {
 	innerHandler slog.Handler
 	extractAttrs []func(context.Context) []slog.Attr
+	name         string
 }
 
 var _ slog.Handler = (*wrappedSlogHandler)(nil)
 
+func (h *wrappedSlogHandler) withName(name string) *wrappedSlogHandler {
+	return &wrappedSlogHandler{innerHandler: h.innerHandler, extractAttrs: h.extractAttrs, name: name}
+}
+
 func (h *wrappedSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	return h.innerHandler.Enabled(ctx, level)
+	return level >= slog.Level(GetLoggerLevel(h.name)) && h.innerHandler.Enabled(ctx, level)
 }
 
 func (h *wrappedSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.name != "" {
+		record.AddAttrs(slog.String("logger", h.name))
+	}
 	for _, extract := range h.extractAttrs {
 		if attrs := extract(ctx); attrs != nil {
 			record.AddAttrs(attrs...)
@@ -108,9 +150,75 @@ func (h *wrappedSlogHandler) Handle(ctx context.Context, record slog.Record) err
 }
 
 func (h *wrappedSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &wrappedSlogHandler{innerHandler: h.innerHandler.WithAttrs(attrs)}
+	return &wrappedSlogHandler{innerHandler: h.innerHandler.WithAttrs(attrs), extractAttrs: h.extractAttrs, name: h.name}
 }
 
 func (h *wrappedSlogHandler) WithGroup(name string) slog.Handler {
-	return &wrappedSlogHandler{innerHandler: h.innerHandler.WithGroup(name)}
+	return &wrappedSlogHandler{innerHandler: h.innerHandler.WithGroup(name), extractAttrs: h.extractAttrs, name: h.name}
+}
+
+// levelSlogHandler is a slog.Handler that drops records below the given level.
+type levelSlogHandler struct {
+	innerHandler slog.Handler
+	level        slog.Leveler
+}
+
+var _ slog.Handler = (*levelSlogHandler)(nil)
+
+func (h *levelSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.innerHandler.Enabled(ctx, level)
+}
+
+func (h *levelSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.innerHandler.Handle(ctx, record)
+}
+
+func (h *levelSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelSlogHandler{innerHandler: h.innerHandler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelSlogHandler) WithGroup(name string) slog.Handler {
+	return &levelSlogHandler{innerHandler: h.innerHandler.WithGroup(name), level: h.level}
+}
+
+// multiSlogHandler is a slog.Handler that fans out records to all enabled handlers.
+type multiSlogHandler []slog.Handler
+
+var _ slog.Handler = multiSlogHandler(nil)
+
+func (h multiSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h multiSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, handler := range h {
+		if handler.Enabled(ctx, record.Level) {
+			if err := handler.Handle(ctx, record.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h multiSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(multiSlogHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (h multiSlogHandler) WithGroup(name string) slog.Handler {
+	handlers := make(multiSlogHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return handlers
 }
@@ -9,20 +9,22 @@ import (
 )
 
 func extractTracingAttrs(ctx context.Context) []slog.Attr {
-	if span := trace.SpanContextFromContext(ctx); span.IsSampled() {
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
 		return []slog.Attr{
 			slog.String("trace.id", span.TraceID().String()),
 			slog.String("span.id", span.SpanID().String()),
+			slog.Bool("trace.sampled", span.IsSampled()),
 		}
 	}
 	return nil
 }
 
 func extractTracingFields(ctx context.Context) []zap.Field {
-	if span := trace.SpanContextFromContext(ctx); span.IsSampled() {
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
 		return []zap.Field{
 			zap.String("trace.id", span.TraceID().String()),
 			zap.String("span.id", span.SpanID().String()),
+			zap.Bool("trace.sampled", span.IsSampled()),
 		}
 	}
 	return nil
@@ -3,10 +3,15 @@ package logging
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
+	"go.opentelemetry.io/contrib/bridges/otelzap"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/choral-io/gommerce-server-core/config"
 )
 
 const badKey = "!BADKEY"
@@ -16,18 +21,172 @@ type ZapLogger struct {
 	logger *zap.Logger
 }
 
-var _ Logger = (*ZapLogger)(nil)
+var _ NamedLogger = (*ZapLogger)(nil)
+
+// NewZapLogger creates a new ZapLogger with the given preset and replaces the global zap logger.
+// The level of the preset is set as the shared logging level, see SetLevel.
+// Logs are written to the outputs of the preset, or to the sinks given by WithSinks.
+func NewZapLogger(preset string, opts ...LoggerOption) (*ZapLogger, error) {
+	zcfg, err := newZapPresetConfig(preset)
+	if err != nil {
+		return nil, err
+	}
+	return newZapLogger(zcfg, nil, newLoggerOptions(opts))
+}
 
-func NewZapLogger(preset string) (*ZapLogger, error) {
-	var f func(options ...zap.Option) (*zap.Logger, error)
+// NewZapLoggerWithConfig creates a new ZapLogger with the given config and replaces the global zap logger.
+// The config starts from the configured preset, and options set in the config override the ones of the preset.
+func NewZapLoggerWithConfig(cfg config.LoggingZapLoggerConfig, opts ...LoggerOption) (*ZapLogger, error) {
+	zcfg, err := newZapPresetConfig(cfg.GetPreset())
+	if err != nil {
+		return nil, err
+	}
+	var zopts []zap.Option
+	if encoding := cfg.GetEncoding(); encoding == "json" || encoding == "console" {
+		zcfg.Encoding = encoding
+	} else if encoding != "" {
+		return nil, fmt.Errorf("unknown zap encoding: %s", encoding)
+	}
+	if level := cfg.GetLevel(); level != "" {
+		if zcfg.Level, err = zap.ParseAtomicLevel(level); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.GetDisableCaller() {
+		zcfg.DisableCaller = true
+	}
+	if level := cfg.GetStacktraceLevel(); level == "none" {
+		zcfg.DisableStacktrace = true
+	} else if level != "" {
+		l, err := zapcore.ParseLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		zcfg.DisableStacktrace = true // replaced by the configured stacktrace level
+		zopts = append(zopts, zap.AddStacktrace(l))
+	}
+	if name := cfg.GetTimeEncoder(); name != "" {
+		if zcfg.EncoderConfig.EncodeTime, err = parseZapTimeEncoder(name); err != nil {
+			return nil, err
+		}
+	}
+	if name := cfg.GetLevelEncoder(); name != "" {
+		if zcfg.EncoderConfig.EncodeLevel, err = parseZapLevelEncoder(name); err != nil {
+			return nil, err
+		}
+	}
+	if name := cfg.GetDurationEncoder(); name != "" {
+		if zcfg.EncoderConfig.EncodeDuration, err = parseZapDurationEncoder(name); err != nil {
+			return nil, err
+		}
+	}
+	if fields := cfg.GetInitialFields(); len(fields) > 0 {
+		zcfg.InitialFields = fields
+	}
+	if paths := cfg.GetOutputPaths(); len(paths) > 0 {
+		zcfg.OutputPaths = paths
+	}
+	if paths := cfg.GetErrorOutputPaths(); len(paths) > 0 {
+		zcfg.ErrorOutputPaths = paths
+	}
+	return newZapLogger(zcfg, zopts, newLoggerOptions(opts))
+}
+
+func newZapPresetConfig(preset string) (zap.Config, error) {
 	if preset == "development" {
-		f = zap.NewDevelopment
+		return zap.NewDevelopmentConfig(), nil
 	} else if preset == "production" {
-		f = zap.NewProduction
-	} else {
-		return nil, errors.New("unknown logging preset")
+		return zap.NewProductionConfig(), nil
 	}
-	if l, err := f(zap.AddCallerSkip(2)); err != nil {
+	return zap.Config{}, errors.New("unknown logging preset")
+}
+
+func parseZapTimeEncoder(name string) (zapcore.TimeEncoder, error) {
+	switch name {
+	case "iso8601":
+		return zapcore.ISO8601TimeEncoder, nil
+	case "rfc3339":
+		return zapcore.RFC3339TimeEncoder, nil
+	case "rfc3339nano":
+		return zapcore.RFC3339NanoTimeEncoder, nil
+	case "epoch":
+		return zapcore.EpochTimeEncoder, nil
+	case "millis":
+		return zapcore.EpochMillisTimeEncoder, nil
+	case "nanos":
+		return zapcore.EpochNanosTimeEncoder, nil
+	}
+	return nil, fmt.Errorf("unknown zap time encoder: %s", name)
+}
+
+func parseZapLevelEncoder(name string) (zapcore.LevelEncoder, error) {
+	switch name {
+	case "lowercase":
+		return zapcore.LowercaseLevelEncoder, nil
+	case "capital":
+		return zapcore.CapitalLevelEncoder, nil
+	case "color":
+		return zapcore.LowercaseColorLevelEncoder, nil
+	case "capitalColor":
+		return zapcore.CapitalColorLevelEncoder, nil
+	}
+	return nil, fmt.Errorf("unknown zap level encoder: %s", name)
+}
+
+func parseZapDurationEncoder(name string) (zapcore.DurationEncoder, error) {
+	switch name {
+	case "string":
+		return zapcore.StringDurationEncoder, nil
+	case "seconds":
+		return zapcore.SecondsDurationEncoder, nil
+	case "nanos":
+		return zapcore.NanosDurationEncoder, nil
+	case "ms":
+		return zapcore.MillisDurationEncoder, nil
+	}
+	return nil, fmt.Errorf("unknown zap duration encoder: %s", name)
+}
+
+func newZapLogger(zcfg zap.Config, zopts []zap.Option, o *loggerOptions) (*ZapLogger, error) {
+	sampler := o.sampler
+	if sampler == nil && zcfg.Sampling != nil {
+		// the sampling of the preset is applied after sinks and the bridge as well
+		sampler = &logSampler{interval: time.Second, first: uint64(zcfg.Sampling.Initial), thereafter: uint64(zcfg.Sampling.Thereafter)}
+	}
+	zcfg.Sampling = nil
+	SetLevel(Level(zcfg.Level.Level() * 4))
+	zcfg.Level = zapMinLevel
+	zopts = append([]zap.Option{zap.AddCallerSkip(2)}, zopts...)
+	if len(o.sinks) > 0 {
+		zopts = append(zopts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			// initial fields are added to the replaced core by zap, so they are added to sinks again
+			return withZapInitialFields(newZapSinkCore(o.sinks, zcfg.EncoderConfig, zcfg.Level), zcfg)
+		}))
+	}
+	if o.provider != nil {
+		zopts = append(zopts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			// the bridged core is enabled by the provider, so it is limited to the levels of the original core as well,
+			// and initial fields are added to it like the original core
+			var bridge zapcore.Core = &levelLimitedZapCore{
+				Core:    otelzap.NewCore(instrumentationName, otelzap.WithLoggerProvider(o.provider)),
+				enabler: core,
+			}
+			bridge = withZapInitialFields(bridge, zcfg)
+			if o.exclusive {
+				return bridge
+			}
+			return zapcore.NewTee(core, bridge)
+		}))
+	}
+	if sampler != nil {
+		zopts = append(zopts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newZapSamplingCore(core, sampler)
+		}))
+	}
+	zopts = append(zopts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &namedLevelZapCore{Core: core}
+	}))
+	if l, err := zcfg.Build(zopts...); err != nil {
 		return nil, err
 	} else {
 		zap.ReplaceGlobals(l)
@@ -35,6 +194,61 @@ func NewZapLogger(preset string) (*ZapLogger, error) {
 	return &ZapLogger{logger: zap.L()}, nil
 }
 
+// withZapInitialFields returns core with the initial fields of the given config.
+func withZapInitialFields(core zapcore.Core, zcfg zap.Config) zapcore.Core {
+	if len(zcfg.InitialFields) == 0 {
+		return core
+	}
+	fields := make([]zap.Field, 0, len(zcfg.InitialFields))
+	for k, v := range zcfg.InitialFields {
+		fields = append(fields, zap.Any(k, v))
+	}
+	return core.With(fields)
+}
+
+// levelLimitedZapCore is a zapcore.Core enabled for levels enabled by both the core and the enabler.
+type levelLimitedZapCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func (c *levelLimitedZapCore) Enabled(level zapcore.Level) bool {
+	return c.enabler.Enabled(level) && c.Core.Enabled(level)
+}
+
+func (c *levelLimitedZapCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelLimitedZapCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+func (c *levelLimitedZapCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabler.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// namedLevelZapCore is a zapcore.Core that checks the precise level of the named logger.
+type namedLevelZapCore struct {
+	zapcore.Core
+}
+
+func (c *namedLevelZapCore) With(fields []zapcore.Field) zapcore.Core {
+	return &namedLevelZapCore{Core: c.Core.With(fields)}
+}
+
+func (c *namedLevelZapCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if Level(entry.Level*4) < GetLoggerLevel(entry.LoggerName) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// contextField returns a field carrying ctx, it is skipped by encoders,
+// but used by the opentelemetry bridge to correlate records with traces.
+func contextField(ctx context.Context) zap.Field {
+	return zap.Field{Key: "context", Type: zapcore.SkipType, Interface: ctx}
+}
+
 func argsToFields(args []any) []zap.Field {
 	var fields []zap.Field
 	for len(args) > 0 {
@@ -65,11 +279,21 @@ func (l *ZapLogger) With(args ...any) Logger {
 	return &ZapLogger{logger: l.logger.With(argsToFields(args)...)}
 }
 
+func (l *ZapLogger) Named(name string) Logger {
+	return &ZapLogger{logger: l.logger.Named(name)}
+}
+
 func (l *ZapLogger) log(ctx context.Context, level Level, message string, args ...any) {
 	fields := argsToFields(args)
 	if fs := extractTracingFields(ctx); len(fs) > 0 {
 		fields = append(fields, fs...)
 	}
+	if fs := extractContextFields(ctx); len(fs) > 0 {
+		fields = append(fields, fs...)
+	}
+	if ctx != nil {
+		fields = append(fields, contextField(ctx))
+	}
 	l.logger.Log(zapcore.Level(level/4), message, fields...)
 }
 
@@ -0,0 +1,51 @@
+package otel
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+// newTLSConfig creates a new tls.Config with the given config.
+// The system cert pool is used if no CA file is specified.
+func newTLSConfig(cfg config.ExporterTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         cfg.GetServerName(),
+		InsecureSkipVerify: cfg.GetInsecureSkipVerify(),
+		MinVersion:         tls.VersionTLS12,
+	}
+	if path := cfg.GetCAFile(); path != "" {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA certificates: %s", path)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if certFile, keyFile := cfg.GetCertFile(), cfg.GetKeyFile(); certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("both cert file and key file are required for client certificate")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// validateCompression checks whether the given compression is supported by exporters.
+func validateCompression(compression string) error {
+	if compression != "none" && compression != "gzip" {
+		return fmt.Errorf("invalid exporter compression: %s", compression)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	stdout "go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+// NewLoggerProvider creates a new LoggerProvider instance with the given config.
+// A no-op LoggerProvider is returned if the exporter protocol is noop.
+func NewLoggerProvider(cfg config.LoggingConfig, res *resource.Resource) (log.LoggerProvider, error) {
+	ctx := context.Background()
+	ecfg := cfg.GetExporterConfig()
+	protocol := ecfg.GetProtocol()
+	var exporter sdklog.Exporter
+	var err error
+	if protocol == "otlp-grpc" {
+		exporter, err = newOTLPLogGRPCExporter(ctx, ecfg)
+	} else if protocol == "otlp-http" {
+		exporter, err = newOTLPLogHTTPExporter(ctx, ecfg)
+	} else if protocol == "stdout" {
+		exporter, err = stdout.New(stdout.WithPrettyPrint())
+	} else if protocol == "noop" {
+		return noop.NewLoggerProvider(), nil
+	} else {
+		return nil, fmt.Errorf("invalid logging exporter protocol: %s", protocol)
+	}
+	if err != nil {
+		return nil, err
+	}
+	loggerProvider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+	)
+	return loggerProvider, nil
+}
+
+func newOTLPLogGRPCExporter(ctx context.Context, cfg config.LoggingExporterConfig) (sdklog.Exporter, error) {
+	if err := validateCompression(cfg.GetCompression()); err != nil {
+		return nil, err
+	}
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpoint(cfg.GetEndpoint()),
+		otlploggrpc.WithHeaders(cfg.GetHeaders()),
+		otlploggrpc.WithTimeout(cfg.GetTimeout()),
+		otlploggrpc.WithRetry(otlploggrpc.RetryConfig{
+			Enabled:         cfg.GetRetryConfig().GetEnabled(),
+			InitialInterval: cfg.GetRetryConfig().GetInitialInterval(),
+			MaxInterval:     cfg.GetRetryConfig().GetMaxInterval(),
+			MaxElapsedTime:  cfg.GetRetryConfig().GetMaxElapsedTime(),
+		}),
+	}
+	if cfg.GetCompression() == "gzip" {
+		opts = append(opts, otlploggrpc.WithCompressor("gzip"))
+	}
+	if cfg.GetInsecure() {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	} else if tlsConfig, err := newTLSConfig(cfg.GetTLSConfig()); err != nil {
+		return nil, err
+	} else {
+		opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	return otlploggrpc.New(ctx, opts...)
+}
+
+func newOTLPLogHTTPExporter(ctx context.Context, cfg config.LoggingExporterConfig) (sdklog.Exporter, error) {
+	if err := validateCompression(cfg.GetCompression()); err != nil {
+		return nil, err
+	}
+	opts := []otlploghttp.Option{
+		otlploghttp.WithEndpoint(cfg.GetEndpoint()),
+		otlploghttp.WithHeaders(cfg.GetHeaders()),
+		otlploghttp.WithTimeout(cfg.GetTimeout()),
+		otlploghttp.WithRetry(otlploghttp.RetryConfig{
+			Enabled:         cfg.GetRetryConfig().GetEnabled(),
+			InitialInterval: cfg.GetRetryConfig().GetInitialInterval(),
+			MaxInterval:     cfg.GetRetryConfig().GetMaxInterval(),
+			MaxElapsedTime:  cfg.GetRetryConfig().GetMaxElapsedTime(),
+		}),
+	}
+	if cfg.GetCompression() == "gzip" {
+		opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+	if path := cfg.GetURLPath(); path != "" {
+		opts = append(opts, otlploghttp.WithURLPath(path))
+	}
+	if cfg.GetInsecure() {
+		opts = append(opts, otlploghttp.WithInsecure())
+	} else if tlsConfig, err := newTLSConfig(cfg.GetTLSConfig()); err != nil {
+		return nil, err
+	} else {
+		opts = append(opts, otlploghttp.WithTLSClientConfig(tlsConfig))
+	}
+	return otlploghttp.New(ctx, opts...)
+}
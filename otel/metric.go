@@ -3,53 +3,133 @@ package otel
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	stdout "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/choral-io/gommerce-server-core/config"
+	"github.com/choral-io/gommerce-server-core/logging"
 )
 
 // NewMeterProvider creates a new MeterProvider instance with the given config.
+// Configured views are applied to instruments created by meters of the provider, including runtime and process metrics,
+// but not to metrics of the runtime producer, such as go.schedule.duration, which are exported as they are produced.
+// Metrics of the logging package, such as log.records.dropped, are registered as well.
 func NewMeterProvider(cfg config.MetricConfig, res *resource.Resource) (metric.MeterProvider, error) {
 	ctx := context.Background()
-	protocol := cfg.GetExporterConfig().GetProtocol()
+	ecfg := cfg.GetExporterConfig()
+	protocol := ecfg.GetProtocol()
 	var exporter sdkmetric.Exporter
 	var err error
 	if protocol == "otlp-grpc" {
-		exporter, err = otlpmetricgrpc.New(ctx,
-			otlpmetricgrpc.WithEndpoint(cfg.GetExporterConfig().GetEndpoint()),
-			otlpmetricgrpc.WithInsecure(),
-			otlpmetricgrpc.WithTimeout(2*time.Second),
-		)
+		exporter, err = newOTLPMetricGRPCExporter(ctx, ecfg)
 	} else if protocol == "otlp-http" {
-		exporter, err = otlpmetrichttp.New(ctx,
-			otlpmetrichttp.WithEndpoint(cfg.GetExporterConfig().GetEndpoint()),
-			otlpmetrichttp.WithInsecure(),
-			otlpmetrichttp.WithTimeout(2*time.Second),
-		)
+		exporter, err = newOTLPMetricHTTPExporter(ctx, ecfg)
 	} else if protocol == "stdout" {
 		exporter, err = stdout.New(stdout.WithPrettyPrint())
 	} else if protocol == "noop" {
 		exporter = nil
 	} else {
-		return nil, fmt.Errorf("invalid trace exporter protocol: %s", protocol)
+		return nil, fmt.Errorf("invalid metric exporter protocol: %s", protocol)
 	}
 	if err != nil {
 		return nil, err
 	}
 	var reader sdkmetric.Reader = nil
 	if exporter != nil {
-		reader = sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(time.Second*5))
+		ropts := []sdkmetric.PeriodicReaderOption{sdkmetric.WithInterval(ecfg.GetInterval())}
+		if cfg.GetRuntimeMetrics() {
+			// scheduler latency histogram is only available from the runtime producer
+			ropts = append(ropts, sdkmetric.WithProducer(runtime.NewProducer()))
+		}
+		reader = sdkmetric.NewPeriodicReader(exporter, ropts...)
+	}
+	views, err := NewViews(cfg.GetViews())
+	if err != nil {
+		return nil, err
 	}
 	meterProvider := sdkmetric.NewMeterProvider(
 		sdkmetric.WithResource(res),
 		sdkmetric.WithReader(reader),
+		sdkmetric.WithView(views...),
 	)
+	if cfg.GetRuntimeMetrics() {
+		if err := runtime.Start(runtime.WithMeterProvider(meterProvider)); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.GetProcessMetrics() {
+		if err := startProcessMetrics(meterProvider); err != nil {
+			return nil, err
+		}
+	}
+	if err := logging.RegisterMetrics(meterProvider); err != nil {
+		return nil, err
+	}
 	return meterProvider, nil
 }
+
+func newOTLPMetricGRPCExporter(ctx context.Context, cfg config.MetricExporterConfig) (sdkmetric.Exporter, error) {
+	if err := validateCompression(cfg.GetCompression()); err != nil {
+		return nil, err
+	}
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(cfg.GetEndpoint()),
+		otlpmetricgrpc.WithHeaders(cfg.GetHeaders()),
+		otlpmetricgrpc.WithTimeout(cfg.GetTimeout()),
+		otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig{
+			Enabled:         cfg.GetRetryConfig().GetEnabled(),
+			InitialInterval: cfg.GetRetryConfig().GetInitialInterval(),
+			MaxInterval:     cfg.GetRetryConfig().GetMaxInterval(),
+			MaxElapsedTime:  cfg.GetRetryConfig().GetMaxElapsedTime(),
+		}),
+	}
+	if cfg.GetCompression() == "gzip" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+	}
+	if cfg.GetInsecure() {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	} else if tlsConfig, err := newTLSConfig(cfg.GetTLSConfig()); err != nil {
+		return nil, err
+	} else {
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
+func newOTLPMetricHTTPExporter(ctx context.Context, cfg config.MetricExporterConfig) (sdkmetric.Exporter, error) {
+	if err := validateCompression(cfg.GetCompression()); err != nil {
+		return nil, err
+	}
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(cfg.GetEndpoint()),
+		otlpmetrichttp.WithHeaders(cfg.GetHeaders()),
+		otlpmetrichttp.WithTimeout(cfg.GetTimeout()),
+		otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
+			Enabled:         cfg.GetRetryConfig().GetEnabled(),
+			InitialInterval: cfg.GetRetryConfig().GetInitialInterval(),
+			MaxInterval:     cfg.GetRetryConfig().GetMaxInterval(),
+			MaxElapsedTime:  cfg.GetRetryConfig().GetMaxElapsedTime(),
+		}),
+	}
+	if cfg.GetCompression() == "gzip" {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+	if path := cfg.GetURLPath(); path != "" {
+		opts = append(opts, otlpmetrichttp.WithURLPath(path))
+	}
+	if cfg.GetInsecure() {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	} else if tlsConfig, err := newTLSConfig(cfg.GetTLSConfig()); err != nil {
+		return nil, err
+	} else {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsConfig))
+	}
+	return otlpmetrichttp.New(ctx, opts...)
+}
@@ -0,0 +1,4 @@
+package otel
+
+// instrumentationName is the instrumentation scope name of meters and tracers created by this module.
+const instrumentationName = "github.com/choral-io/gommerce-server-core"
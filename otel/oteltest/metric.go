@@ -0,0 +1,108 @@
+package oteltest
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Collect collects and returns all metrics recorded so far.
+func (t *Telemetry) Collect(tb testing.TB) metricdata.ResourceMetrics {
+	tb.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := t.reader.Collect(context.Background(), &rm); err != nil {
+		tb.Fatalf("failed to collect metrics: %v", err)
+	}
+	return rm
+}
+
+// FindMetric collects metrics and returns the first one with the given name.
+func (t *Telemetry) FindMetric(tb testing.TB, name string) (metricdata.Metrics, bool) {
+	tb.Helper()
+	rm := t.Collect(tb)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+	return metricdata.Metrics{}, false
+}
+
+// RequireMetric collects metrics and returns the first one with the given name, or fails the test immediately.
+func (t *Telemetry) RequireMetric(tb testing.TB, name string) metricdata.Metrics {
+	tb.Helper()
+	m, ok := t.FindMetric(tb, name)
+	if !ok {
+		tb.Fatalf("metric %q not found", name)
+	}
+	return m
+}
+
+// Int64DataPoints returns the data points of an int64 sum or gauge metric.
+func Int64DataPoints(m metricdata.Metrics) []metricdata.DataPoint[int64] {
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		return data.DataPoints
+	case metricdata.Gauge[int64]:
+		return data.DataPoints
+	}
+	return nil
+}
+
+// Float64DataPoints returns the data points of a float64 sum or gauge metric.
+func Float64DataPoints(m metricdata.Metrics) []metricdata.DataPoint[float64] {
+	switch data := m.Data.(type) {
+	case metricdata.Sum[float64]:
+		return data.DataPoints
+	case metricdata.Gauge[float64]:
+		return data.DataPoints
+	}
+	return nil
+}
+
+// HistogramDataPoints returns the data points of a float64 histogram metric.
+func HistogramDataPoints(m metricdata.Metrics) []metricdata.HistogramDataPoint[float64] {
+	if data, ok := m.Data.(metricdata.Histogram[float64]); ok {
+		return data.DataPoints
+	}
+	return nil
+}
+
+// RequireInt64Sum returns the sum of all int64 data points of the named metric having all the given attributes,
+// or fails the test immediately if the metric is not found.
+func (t *Telemetry) RequireInt64Sum(tb testing.TB, name string, attrs ...attribute.KeyValue) int64 {
+	tb.Helper()
+	var sum int64
+	for _, dp := range Int64DataPoints(t.RequireMetric(tb, name)) {
+		if hasAttributes(dp.Attributes, attrs) {
+			sum += dp.Value
+		}
+	}
+	return sum
+}
+
+// RequireHistogramCount returns the total count of all histogram data points of the named metric having all the given attributes,
+// or fails the test immediately if the metric is not found.
+func (t *Telemetry) RequireHistogramCount(tb testing.TB, name string, attrs ...attribute.KeyValue) uint64 {
+	tb.Helper()
+	var count uint64
+	for _, dp := range HistogramDataPoints(t.RequireMetric(tb, name)) {
+		if hasAttributes(dp.Attributes, attrs) {
+			count += dp.Count
+		}
+	}
+	return count
+}
+
+func hasAttributes(set attribute.Set, attrs []attribute.KeyValue) bool {
+	for _, want := range attrs {
+		if got, ok := set.Value(want.Key); !ok || got != want.Value {
+			return false
+		}
+	}
+	return true
+}
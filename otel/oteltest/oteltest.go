@@ -0,0 +1,73 @@
+// Package oteltest provides in-memory trace and metric providers for testing instrumented code.
+//
+// A typical usage in a test looks like:
+//
+//	tel := oteltest.New(t)
+//	h, err := server.NewGRPCHandler(cfg, tel.GRPCHandlerOption(), ...)
+//	// ... call the handler ...
+//	span := tel.RequireSpan(t, "foo.v1.FooService/GetFoo")
+//	tel.AssertSpanAttributes(t, span, attribute.String("rpc.service", "foo.v1.FooService"))
+//	tel.RequireInt64Sum(t, "rpc.server.identity.calls")
+package oteltest
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/choral-io/gommerce-server-core/server"
+)
+
+// Telemetry holds a TracerProvider and a MeterProvider backed by in-memory exporter and reader.
+type Telemetry struct {
+	exporter *tracetest.InMemoryExporter
+	reader   *sdkmetric.ManualReader
+	tp       *sdktrace.TracerProvider
+	mp       *sdkmetric.MeterProvider
+}
+
+// New creates a new Telemetry instance, the providers are shut down when the test finishes.
+// Spans are exported synchronously, so they are visible as soon as they are ended.
+func New(tb testing.TB) *Telemetry {
+	tb.Helper()
+	t := &Telemetry{
+		exporter: tracetest.NewInMemoryExporter(),
+		reader:   sdkmetric.NewManualReader(),
+	}
+	t.tp = sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSyncer(t.exporter),
+	)
+	t.mp = sdkmetric.NewMeterProvider(sdkmetric.WithReader(t.reader))
+	tb.Cleanup(func() {
+		ctx := context.Background()
+		_ = t.tp.Shutdown(ctx)
+		_ = t.mp.Shutdown(ctx)
+	})
+	return t
+}
+
+// TracerProvider returns the in-memory TracerProvider.
+func (t *Telemetry) TracerProvider() trace.TracerProvider {
+	return t.tp
+}
+
+// MeterProvider returns the in-memory MeterProvider.
+func (t *Telemetry) MeterProvider() metric.MeterProvider {
+	return t.mp
+}
+
+// GRPCHandlerOption returns a GRPCHandlerOption that instruments GRPCHandler with the in-memory providers.
+func (t *Telemetry) GRPCHandlerOption() server.GRPCHandlerOption {
+	return server.WithOTELStatsHandler(t.tp, t.mp)
+}
+
+// Reset removes all recorded spans.
+func (t *Telemetry) Reset() {
+	t.exporter.Reset()
+}
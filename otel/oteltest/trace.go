@@ -0,0 +1,85 @@
+package oteltest
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Spans returns all ended spans in the order they ended.
+func (t *Telemetry) Spans() tracetest.SpanStubs {
+	return t.exporter.GetSpans()
+}
+
+// FindSpans returns all ended spans with the given name.
+func (t *Telemetry) FindSpans(name string) tracetest.SpanStubs {
+	var spans tracetest.SpanStubs
+	for _, span := range t.exporter.GetSpans() {
+		if span.Name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// FindSpan returns the first ended span with the given name.
+func (t *Telemetry) FindSpan(name string) (tracetest.SpanStub, bool) {
+	for _, span := range t.exporter.GetSpans() {
+		if span.Name == name {
+			return span, true
+		}
+	}
+	return tracetest.SpanStub{}, false
+}
+
+// RequireSpan returns the first ended span with the given name, or fails the test immediately.
+func (t *Telemetry) RequireSpan(tb testing.TB, name string) tracetest.SpanStub {
+	tb.Helper()
+	span, ok := t.FindSpan(name)
+	if !ok {
+		tb.Fatalf("span %q not found, recorded spans: %v", name, spanNames(t.exporter.GetSpans()))
+	}
+	return span
+}
+
+// SpanAttribute returns the value of the attribute with the given key on the span.
+func SpanAttribute(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+// AssertSpanAttributes reports an error for every expected attribute missing on the span or having a different value.
+func (t *Telemetry) AssertSpanAttributes(tb testing.TB, span tracetest.SpanStub, attrs ...attribute.KeyValue) {
+	tb.Helper()
+	for _, want := range attrs {
+		got, ok := SpanAttribute(span, want.Key)
+		if !ok {
+			tb.Errorf("span %q: attribute %q not found", span.Name, want.Key)
+		} else if got != want.Value {
+			tb.Errorf("span %q: attribute %q = %s, want %s", span.Name, want.Key, got.Emit(), want.Value.Emit())
+		}
+	}
+}
+
+// AssertNoSpanAttributes reports an error for every given key present on the span.
+func (t *Telemetry) AssertNoSpanAttributes(tb testing.TB, span tracetest.SpanStub, keys ...attribute.Key) {
+	tb.Helper()
+	for _, key := range keys {
+		if got, ok := SpanAttribute(span, key); ok {
+			tb.Errorf("span %q: unexpected attribute %q = %s", span.Name, key, got.Emit())
+		}
+	}
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name
+	}
+	return names
+}
@@ -0,0 +1,69 @@
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// processStats is a snapshot of the resource usage of the current process.
+type processStats struct {
+	userTime      float64 // user cpu time in seconds
+	systemTime    float64 // system cpu time in seconds
+	residentBytes int64   // resident memory in bytes
+	virtualBytes  int64   // virtual memory in bytes
+	openFiles     int64   // number of open file descriptors
+}
+
+var (
+	cpuModeUser   = metric.WithAttributeSet(attribute.NewSet(attribute.String("cpu.mode", "user")))
+	cpuModeSystem = metric.WithAttributeSet(attribute.NewSet(attribute.String("cpu.mode", "system")))
+)
+
+// startProcessMetrics registers process cpu, memory and file descriptor metrics on the given MeterProvider.
+// Metrics are not reported if the platform is not supported.
+func startProcessMetrics(mp metric.MeterProvider) error {
+	meter := mp.Meter(instrumentationName)
+	cpuTime, err := meter.Float64ObservableCounter("process.cpu.time",
+		metric.WithUnit("s"),
+		metric.WithDescription("Total CPU seconds broken down by different CPU modes."),
+	)
+	if err != nil {
+		return err
+	}
+	memoryUsage, err := meter.Int64ObservableUpDownCounter("process.memory.usage",
+		metric.WithUnit("By"),
+		metric.WithDescription("The amount of physical memory in use."),
+	)
+	if err != nil {
+		return err
+	}
+	memoryVirtual, err := meter.Int64ObservableUpDownCounter("process.memory.virtual",
+		metric.WithUnit("By"),
+		metric.WithDescription("The amount of committed virtual memory."),
+	)
+	if err != nil {
+		return err
+	}
+	openFiles, err := meter.Int64ObservableUpDownCounter("process.open_file_descriptor.count",
+		metric.WithUnit("{count}"),
+		metric.WithDescription("Number of file descriptors in use by the process."),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats, err := readProcessStats()
+		if err != nil {
+			return nil // unsupported platform, nothing to report
+		}
+		o.ObserveFloat64(cpuTime, stats.userTime, cpuModeUser)
+		o.ObserveFloat64(cpuTime, stats.systemTime, cpuModeSystem)
+		o.ObserveInt64(memoryUsage, stats.residentBytes)
+		o.ObserveInt64(memoryVirtual, stats.virtualBytes)
+		o.ObserveInt64(openFiles, stats.openFiles)
+		return nil
+	}, cpuTime, memoryUsage, memoryVirtual, openFiles)
+	return err
+}
@@ -0,0 +1,37 @@
+//go:build linux
+
+package otel
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+func readProcessStats() (processStats, error) {
+	var stats processStats
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return stats, err
+	}
+	stats.userTime = float64(usage.Utime.Sec) + float64(usage.Utime.Usec)/1e6
+	stats.systemTime = float64(usage.Stime.Sec) + float64(usage.Stime.Usec)/1e6
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return stats, err
+	}
+	var size, resident int64
+	if _, err := fmt.Sscan(string(statm), &size, &resident); err != nil {
+		return stats, err
+	}
+	pageSize := int64(os.Getpagesize())
+	stats.virtualBytes = size * pageSize
+	stats.residentBytes = resident * pageSize
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return stats, err
+	}
+	// the descriptor of /proc/self/fd opened by ReadDir is listed too
+	stats.openFiles = int64(len(fds)) - 1
+	return stats, nil
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package otel
+
+import (
+	"errors"
+)
+
+func readProcessStats() (processStats, error) {
+	return processStats{}, errors.New("process metrics are not supported on this platform")
+}
@@ -0,0 +1,35 @@
+package otel
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+// NewPropagator creates a new composite TextMapPropagator with the given config.
+// Supported propagators are: tracecontext, baggage, b3 (single header), b3multi (multiple headers) and jaeger.
+func NewPropagator(cfg config.TraceConfig) (propagation.TextMapPropagator, error) {
+	names := cfg.GetPropagators()
+	props := make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "tracecontext":
+			props = append(props, propagation.TraceContext{})
+		case "baggage":
+			props = append(props, propagation.Baggage{})
+		case "b3":
+			props = append(props, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case "b3multi":
+			props = append(props, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case "jaeger":
+			props = append(props, jaeger.Jaeger{})
+		default:
+			return nil, fmt.Errorf("invalid trace propagator: %s", name)
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(props...), nil
+}
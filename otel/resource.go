@@ -2,7 +2,9 @@ package otel
 
 import (
 	"context"
+	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
@@ -10,10 +12,54 @@ import (
 )
 
 // NewServerResource creates a new resource with the given config.
+// Attributes are merged in the following order, the latter takes precedence:
+// detectors, configured attributes, service attributes and environment variables
+// (OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME) if the env detector is enabled.
 func NewServerResource(cfg config.ServerConfig) (*resource.Resource, error) {
-	return resource.New(context.Background(), resource.WithAttributes(
+	rcfg := cfg.GetResourceConfig()
+	opts := []resource.Option{resource.WithTelemetrySDK()}
+	fromEnv := false
+	for _, name := range rcfg.GetDetectors() {
+		switch name {
+		case "host":
+			opts = append(opts, resource.WithHost())
+		case "os":
+			opts = append(opts, resource.WithOS())
+		case "process":
+			// command args are excluded, they may contain secrets
+			opts = append(opts,
+				resource.WithProcessPID(),
+				resource.WithProcessExecutableName(),
+				resource.WithProcessRuntimeName(),
+				resource.WithProcessRuntimeVersion(),
+				resource.WithProcessRuntimeDescription(),
+			)
+		case "container":
+			opts = append(opts, resource.WithContainer())
+		case "env":
+			fromEnv = true
+		default:
+			return nil, fmt.Errorf("invalid resource detector: %s", name)
+		}
+	}
+	attrs := make([]attribute.KeyValue, 0, len(rcfg.GetAttributes())+5)
+	for k, v := range rcfg.GetAttributes() {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	if ns := rcfg.GetNamespace(); ns != "" {
+		attrs = append(attrs, semconv.ServiceNamespace(ns))
+	}
+	if env := rcfg.GetEnvironment(); env != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironment(env))
+	}
+	attrs = append(attrs,
 		semconv.ServiceName(cfg.GetName()),
 		semconv.ServiceVersion(cfg.GetVersion()),
 		semconv.ServiceInstanceID(cfg.GetInstanceId()),
-	))
+	)
+	opts = append(opts, resource.WithAttributes(attrs...))
+	if fromEnv {
+		opts = append(opts, resource.WithFromEnv())
+	}
+	return resource.New(context.Background(), opts...)
 }
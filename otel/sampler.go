@@ -0,0 +1,87 @@
+package otel
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+// NewSampler creates a new Sampler instance with the given config.
+// Supported sampler types are: always, never, ratio and parent-ratio.
+// Rules are matched against span names (full gRPC method names for server spans) in order,
+// the first matched rule decides the sampler, otherwise the default sampler is used.
+func NewSampler(cfg config.TraceSamplerConfig) (sdktrace.Sampler, error) {
+	fallback, err := newSampler(cfg.GetType(), cfg.GetRatio())
+	if err != nil {
+		return nil, err
+	}
+	rules := cfg.GetRules()
+	if len(rules) == 0 {
+		return fallback, nil
+	}
+	s := &ruleSampler{
+		rules:    make([]samplerRule, 0, len(rules)),
+		fallback: fallback,
+	}
+	for _, rule := range rules {
+		pattern := strings.TrimPrefix(rule.GetMethod(), "/")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid trace sampler rule method: %s", rule.GetMethod())
+		}
+		sampler, err := newSampler(rule.GetType(), rule.GetRatio())
+		if err != nil {
+			return nil, err
+		}
+		s.rules = append(s.rules, samplerRule{pattern: pattern, sampler: sampler})
+	}
+	return s, nil
+}
+
+func newSampler(kind string, ratio float64) (sdktrace.Sampler, error) {
+	switch kind {
+	case "always":
+		return sdktrace.AlwaysSample(), nil
+	case "never":
+		return sdktrace.NeverSample(), nil
+	case "ratio":
+		return sdktrace.TraceIDRatioBased(ratio), nil
+	case "parent-ratio":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
+	}
+	return nil, fmt.Errorf("invalid trace sampler type: %s", kind)
+}
+
+type samplerRule struct {
+	pattern string
+	sampler sdktrace.Sampler
+}
+
+// ruleSampler is a sampler that delegates the sampling decision to the first rule matching the span name.
+type ruleSampler struct {
+	rules    []samplerRule
+	fallback sdktrace.Sampler
+}
+
+var _ sdktrace.Sampler = (*ruleSampler)(nil)
+
+func (s *ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	name := strings.TrimPrefix(p.Name, "/")
+	for _, rule := range s.rules {
+		if ok, _ := path.Match(rule.pattern, name); ok {
+			return rule.sampler.ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *ruleSampler) Description() string {
+	descs := make([]string, len(s.rules))
+	for i, rule := range s.rules {
+		descs[i] = fmt.Sprintf("%s=%s", rule.pattern, rule.sampler.Description())
+	}
+	return fmt.Sprintf("RuleSampler{%s,default=%s}", strings.Join(descs, ","), s.fallback.Description())
+}
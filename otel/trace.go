@@ -3,8 +3,8 @@ package otel
 import (
 	"context"
 	"fmt"
-	"time"
 
+	otelglobal "go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	stdout "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
@@ -12,28 +12,28 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/choral-io/gommerce-server-core/config"
 )
 
 // NewTracerProvider creates a new TracerProvider instance with the given config.
+// The configured propagators are installed as the global TextMapPropagator,
+// so that grpc, gateway, database, redis and nats code paths share them.
 func NewTracerProvider(cfg config.TraceConfig, res *resource.Resource) (trace.TracerProvider, error) {
 	ctx := context.Background()
-	protocol := cfg.GetExporterConfig().GetProtocol()
+	propagator, err := NewPropagator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	otelglobal.SetTextMapPropagator(propagator)
+	ecfg := cfg.GetExporterConfig()
+	protocol := ecfg.GetProtocol()
 	var exporter sdktrace.SpanExporter
-	var err error
 	if protocol == "otlp-grpc" {
-		exporter, err = otlptracegrpc.New(ctx,
-			otlptracegrpc.WithEndpoint(cfg.GetExporterConfig().GetEndpoint()),
-			otlptracegrpc.WithInsecure(),
-			otlptracegrpc.WithTimeout(2*time.Second),
-		)
+		exporter, err = newOTLPTraceGRPCExporter(ctx, ecfg)
 	} else if protocol == "otlp-http" {
-		exporter, err = otlptracehttp.New(ctx,
-			otlptracehttp.WithEndpoint(cfg.GetExporterConfig().GetEndpoint()),
-			otlptracehttp.WithInsecure(),
-			otlptracehttp.WithTimeout(2*time.Second),
-		)
+		exporter, err = newOTLPTraceHTTPExporter(ctx, ecfg)
 	} else if protocol == "stdout" {
 		exporter, err = stdout.New(stdout.WithPrettyPrint())
 	} else if protocol == "noop" {
@@ -44,10 +44,73 @@ func NewTracerProvider(cfg config.TraceConfig, res *resource.Resource) (trace.Tr
 	if err != nil {
 		return nil, err
 	}
+	sampler, err := NewSampler(cfg.GetSamplerConfig())
+	if err != nil {
+		return nil, err
+	}
 	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sampler),
 		sdktrace.WithResource(res),
 		sdktrace.WithBatcher(exporter),
 	)
 	return tracerProvider, nil
 }
+
+func newOTLPTraceGRPCExporter(ctx context.Context, cfg config.TraceExporterConfig) (sdktrace.SpanExporter, error) {
+	if err := validateCompression(cfg.GetCompression()); err != nil {
+		return nil, err
+	}
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.GetEndpoint()),
+		otlptracegrpc.WithHeaders(cfg.GetHeaders()),
+		otlptracegrpc.WithTimeout(cfg.GetTimeout()),
+		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+			Enabled:         cfg.GetRetryConfig().GetEnabled(),
+			InitialInterval: cfg.GetRetryConfig().GetInitialInterval(),
+			MaxInterval:     cfg.GetRetryConfig().GetMaxInterval(),
+			MaxElapsedTime:  cfg.GetRetryConfig().GetMaxElapsedTime(),
+		}),
+	}
+	if cfg.GetCompression() == "gzip" {
+		opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
+	}
+	if cfg.GetInsecure() {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else if tlsConfig, err := newTLSConfig(cfg.GetTLSConfig()); err != nil {
+		return nil, err
+	} else {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+func newOTLPTraceHTTPExporter(ctx context.Context, cfg config.TraceExporterConfig) (sdktrace.SpanExporter, error) {
+	if err := validateCompression(cfg.GetCompression()); err != nil {
+		return nil, err
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.GetEndpoint()),
+		otlptracehttp.WithHeaders(cfg.GetHeaders()),
+		otlptracehttp.WithTimeout(cfg.GetTimeout()),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         cfg.GetRetryConfig().GetEnabled(),
+			InitialInterval: cfg.GetRetryConfig().GetInitialInterval(),
+			MaxInterval:     cfg.GetRetryConfig().GetMaxInterval(),
+			MaxElapsedTime:  cfg.GetRetryConfig().GetMaxElapsedTime(),
+		}),
+	}
+	if cfg.GetCompression() == "gzip" {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	if path := cfg.GetURLPath(); path != "" {
+		opts = append(opts, otlptracehttp.WithURLPath(path))
+	}
+	if cfg.GetInsecure() {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	} else if tlsConfig, err := newTLSConfig(cfg.GetTLSConfig()); err != nil {
+		return nil, err
+	} else {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsConfig))
+	}
+	return otlptracehttp.New(ctx, opts...)
+}
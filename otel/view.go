@@ -0,0 +1,95 @@
+package otel
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+// NewViews creates metric views with the given configs.
+// Supported aggregations are: default, drop, sum, last-value, explicit and exponential.
+func NewViews(cfgs []config.MetricViewConfig) ([]sdkmetric.View, error) {
+	views := make([]sdkmetric.View, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		view, err := newView(cfg)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func newView(cfg config.MetricViewConfig) (sdkmetric.View, error) {
+	name, meter := cfg.GetInstrument(), cfg.GetMeter()
+	if name == "" && meter == "" {
+		return nil, fmt.Errorf("invalid metric view: instrument or meter is required")
+	}
+	// the sdk only logs invalid views and ignores them, fail fast instead
+	if cfg.GetRename() != "" && (name == "" || strings.ContainsAny(name, "*?")) {
+		return nil, fmt.Errorf("invalid metric view: rename requires an exact instrument name: %s", name)
+	}
+	aggregation, err := newAggregation(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sdkmetric.NewView(
+		sdkmetric.Instrument{Name: name, Scope: instrumentation.Scope{Name: meter}},
+		sdkmetric.Stream{
+			Name:            cfg.GetRename(),
+			Aggregation:     aggregation,
+			AttributeFilter: newAttributeFilter(cfg.GetAllowAttributes(), cfg.GetDenyAttributes()),
+		},
+	), nil
+}
+
+func newAggregation(cfg config.MetricViewConfig) (sdkmetric.Aggregation, error) {
+	switch kind := cfg.GetAggregation(); kind {
+	case "default":
+		return nil, nil
+	case "drop":
+		return sdkmetric.AggregationDrop{}, nil
+	case "sum":
+		return sdkmetric.AggregationSum{}, nil
+	case "last-value":
+		return sdkmetric.AggregationLastValue{}, nil
+	case "explicit":
+		if len(cfg.GetBuckets()) == 0 {
+			return nil, fmt.Errorf("invalid metric view: buckets are required for explicit aggregation")
+		}
+		return sdkmetric.AggregationExplicitBucketHistogram{Boundaries: cfg.GetBuckets()}, nil
+	case "exponential":
+		return sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: cfg.GetMaxSize(), MaxScale: cfg.GetMaxScale()}, nil
+	default:
+		return nil, fmt.Errorf("invalid metric view aggregation: %s", kind)
+	}
+}
+
+func newAttributeFilter(allow, deny []string) attribute.Filter {
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil
+	}
+	allowKeys := make(map[attribute.Key]struct{}, len(allow))
+	for _, key := range allow {
+		allowKeys[attribute.Key(key)] = struct{}{}
+	}
+	denyKeys := make(map[attribute.Key]struct{}, len(deny))
+	for _, key := range deny {
+		denyKeys[attribute.Key(key)] = struct{}{}
+	}
+	return func(kv attribute.KeyValue) bool {
+		if _, ok := denyKeys[kv.Key]; ok {
+			return false
+		}
+		if len(allowKeys) == 0 {
+			return true
+		}
+		_, ok := allowKeys[kv.Key]
+		return ok
+	}
+}
@@ -3,8 +3,10 @@ package secure
 import (
 	"context"
 	"strings"
+	"sync"
 
 	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 )
 
 var (
@@ -15,11 +17,17 @@ var (
 type AuthFunc func(user *Identity) error
 
 // Authorize authorizes the identity in the given context with the given auth functions.
+// The grpc call in the context, such as the request message, is available to authorization expressions.
 func Authorize(ctx context.Context, auth ...AuthFunc) error {
 	user := IdentityFromContext(ctx)
 	if user == nil {
 		user = anonymous
 	}
+	if call := callFromContext(ctx); call != nil {
+		withCall := *user
+		withCall.call = call
+		user = &withCall
+	}
 	for _, f := range auth {
 		if err := f(user); err != nil {
 			return err
@@ -30,17 +38,40 @@ func Authorize(ctx context.Context, auth ...AuthFunc) error {
 
 // AuthFuncExpression returns an AuthFunc that evaluates the given expression.
 // The expression must return a boolean.
-// The expression is evaluated with the identity as the context.
+// The expression is evaluated with AuthEnv, the methods of the identity, the method name, the request message,
+// the incoming metadata and the current time of the grpc call, custom functions are added by expr.Function options.
+// The request is always nil for streaming calls, as their messages are received by handlers after authorization.
+// Compiled programs of expressions without options are cached by script, up to maxCachedPrograms,
+// so it can be called per call, such as in Authorize methods of services.
+// Expressions with options are compiled every time, so their AuthFuncs should be created once and reused.
 // Example:
 //
 //	AuthFuncExpression(`Token().Realm() == "default"`)
-func AuthFuncExpression(script string) AuthFunc {
-	program, err := expr.Compile(script, expr.Env(&Identity{}))
+//	AuthFuncExpression(`request.owner_id == Token().Subject() || HasPermission("orders:read")`)
+func AuthFuncExpression(script string, opts ...expr.Option) AuthFunc {
+	f, err := compileAuthFuncExpression(script, opts...)
 	if err != nil {
 		panic(err) // panic here because it's a developer error
 	}
+	return f
+}
+
+// maxCachedPrograms is the maximum number of compiled programs cached for expressions without options.
+const maxCachedPrograms = 1024
+
+var (
+	programsMu sync.Mutex
+	programs   = make(map[string]*vm.Program, maxCachedPrograms) // compiled programs of expressions without options, by script
+)
+
+// compileAuthFuncExpression is like AuthFuncExpression, but returns the error if the expression can not be compiled.
+func compileAuthFuncExpression(script string, opts ...expr.Option) (AuthFunc, error) {
+	program, err := compileProgram(script, opts...)
+	if err != nil {
+		return nil, err
+	}
 	return func(user *Identity) error {
-		if output, err := expr.Run(program, user); err != nil {
+		if output, err := expr.Run(program, newAuthEnv(user)); err != nil {
 			return err
 		} else if result, ok := output.(bool); !ok {
 			return ErrInvalidAuthExprOutput
@@ -48,7 +79,35 @@ func AuthFuncExpression(script string) AuthFunc {
 			return ErrPermissionDenied
 		}
 		return nil
+	}, nil
+}
+
+// compileProgram compiles the given expression, programs of expressions without options are cached.
+// An arbitrary cached program is evicted if the cache is full.
+func compileProgram(script string, opts ...expr.Option) (*vm.Program, error) {
+	if len(opts) > 0 {
+		return expr.Compile(script, append([]expr.Option{expr.Env(&AuthEnv{})}, opts...)...)
+	}
+	programsMu.Lock()
+	program, ok := programs[script]
+	programsMu.Unlock()
+	if ok {
+		return program, nil
+	}
+	program, err := expr.Compile(script, expr.Env(&AuthEnv{}))
+	if err != nil {
+		return nil, err
+	}
+	programsMu.Lock()
+	defer programsMu.Unlock()
+	if len(programs) >= maxCachedPrograms {
+		for key := range programs {
+			delete(programs, key)
+			break
+		}
 	}
+	programs[script] = program
+	return program, nil
 }
 
 // AuthFuncAuthenticated returns an AuthFunc that requires the identity is authenticated.
@@ -2,10 +2,44 @@ package secure
 
 import (
 	"context"
+
+	"github.com/choral-io/gommerce-server-core/logging"
 )
 
 type identityKey struct{}
 
+type identityCaptureKey struct{}
+
+// ContextWithIdentityCapture returns a copy of ctx, and a function returning the identity resolved by ServerAuthorizer
+// for calls made with the returned context, even if the call is denied afterwards.
+// It is used by interceptors chained before the secure interceptor, such as auditing.
+func ContextWithIdentityCapture(ctx context.Context) (context.Context, func() *Identity) {
+	var user *Identity
+	return context.WithValue(ctx, identityCaptureKey{}, &user), func() *Identity { return user }
+}
+
+// contextWithIdentity returns a copy of ctx carrying the given identity,
+// the subject of the identity is added to every record logged with the returned context if logSubject is true.
+func contextWithIdentity(ctx context.Context, user *Identity, logSubject bool) context.Context {
+	if captured, ok := ctx.Value(identityCaptureKey{}).(**Identity); ok {
+		*captured = user
+	}
+	ctx = context.WithValue(ctx, identityKey{}, user)
+	if logSubject && user.token != nil && user.token.subject != "" {
+		ctx = logging.ContextWith(ctx, "enduser.id", user.token.subject)
+	}
+	return ctx
+}
+
+// WithLogSubject returns a ServerAuthorizerOption that sets whether the subject of resolved identities is added
+// to every record logged during calls as enduser.id, it is enabled by default and can be disabled for privacy.
+func WithLogSubject(enabled bool) ServerAuthorizerOption {
+	return func(auth *ServerAuthorizer) error {
+		auth.logSubject = enabled
+		return nil
+	}
+}
+
 // IdentityFromContext returns the identity from the given context.
 func IdentityFromContext(ctx context.Context) *Identity {
 	if id, ok := ctx.Value(identityKey{}).(*Identity); ok {
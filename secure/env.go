@@ -0,0 +1,125 @@
+package secure
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AuthEnv is the environment that authorization expressions are evaluated with, see AuthFuncExpression.
+// Methods of the identity are available at the top level, such as Token().Subject().
+type AuthEnv struct {
+	*Identity
+	// Method is the full method name of the grpc call, empty outside grpc calls.
+	Method string `expr:"method"`
+	// Request is the request message of the grpc call by field names, nil for streaming calls and outside grpc calls.
+	Request map[string]any `expr:"request"`
+	// Metadata is the first values of incoming metadata by lower case keys, except the authorization header.
+	Metadata map[string]string `expr:"metadata"`
+	// Now is the time that the expression is evaluated.
+	Now time.Time `expr:"now"`
+}
+
+type callKey struct{}
+
+// callInfo is the grpc call being authorized, carried by the context of the call.
+type callInfo struct {
+	method   string
+	req      any
+	metadata map[string]string
+
+	once    sync.Once
+	request map[string]any // converted from req lazily
+}
+
+// contextWithCall returns a copy of ctx carrying the grpc call of the given method and request message.
+func contextWithCall(ctx context.Context, method string, req any) context.Context {
+	call := &callInfo{method: method, req: req, metadata: make(map[string]string)}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		if len(values) > 0 && !strings.EqualFold(key, AuthHeaderKey) {
+			call.metadata[strings.ToLower(key)] = values[0]
+		}
+	}
+	return context.WithValue(ctx, callKey{}, call)
+}
+
+func callFromContext(ctx context.Context) *callInfo {
+	call, _ := ctx.Value(callKey{}).(*callInfo)
+	return call
+}
+
+// newAuthEnv returns the environment of the given identity, with the grpc call of the identity if there is one.
+func newAuthEnv(user *Identity) *AuthEnv {
+	env := &AuthEnv{Identity: user, Now: time.Now()}
+	if call := user.call; call != nil {
+		env.Method = call.method
+		env.Metadata = call.metadata
+		call.once.Do(func() {
+			if msg, ok := call.req.(proto.Message); ok {
+				call.request = messageToMap(msg.ProtoReflect())
+			}
+		})
+		env.Request = call.request
+	}
+	return env
+}
+
+// messageToMap converts the given message to a map by field names, including fields with default values.
+// Enums are converted to their names, and unset message fields to nil.
+func messageToMap(m protoreflect.Message) map[string]any {
+	fields := m.Descriptor().Fields()
+	result := make(map[string]any, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && !m.Has(fd) {
+			result[string(fd.Name())] = nil
+			continue
+		}
+		result[string(fd.Name())] = fieldToValue(fd, m.Get(fd))
+	}
+	return result
+}
+
+func fieldToValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		result := make([]any, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			result[i] = singularToValue(fd, list.Get(i))
+		}
+		return result
+	case fd.IsMap():
+		result := make(map[string]any, v.Map().Len())
+		v.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			result[key.String()] = singularToValue(fd.MapValue(), value)
+			return true
+		})
+		return result
+	}
+	return singularToValue(fd, v)
+}
+
+func singularToValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int(v.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageToMap(v.Message())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return int(v.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return uint(v.Uint())
+	}
+	return v.Interface()
+}
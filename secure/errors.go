@@ -29,6 +29,8 @@ const (
 	ErrUnauthenticated = Status("unauthenticated")
 	// ErrPermissionDenied is returned when the user does not have permission to perform the operation.
 	ErrPermissionDenied = Status("permission denied")
+	// ErrPermissionResolution is returned when the permissions of the user can not be resolved.
+	ErrPermissionResolution = Status("failed to resolve permissions")
 )
 
 func (s Status) Error() string {
@@ -50,6 +52,9 @@ func (s Status) GRPCStatus() *status.Status {
 	case
 		ErrPermissionDenied:
 		code = codes.PermissionDenied
+	case
+		ErrPermissionResolution:
+		code = codes.Internal
 	}
 	return status.New(code, s.Error())
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/expr-lang/expr"
 	"github.com/golang-jwt/jwt/v5"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/metadata"
@@ -20,27 +21,52 @@ type authorizer interface {
 
 // ServerAuthorizer provides server-side grpc interceptors for authorization.
 type ServerAuthorizer struct {
-	stores map[string]TokenStore
+	stores     map[string]TokenStore
+	telemetry  *identityTelemetry
+	policies   *PolicyTable
+	options    *methodOptions
+	resolver   PermissionResolver
+	logSubject bool          // whether the subject is added to logging contexts as enduser.id
+	exprOpts   []expr.Option // options of authorization expressions declared by proto options
 }
 
+// ServerAuthorizerOption is an option for ServerAuthorizer, used to configure it.
+type ServerAuthorizerOption func(*ServerAuthorizer) error
+
 // NewServerAuthorizer returns a new ServerAuthorizer with the given token stores.
 // The key of the map stores is the authentication schema.
 func NewServerAuthorizer(stores map[string]TokenStore) *ServerAuthorizer {
-	auth := &ServerAuthorizer{stores: make(map[string]TokenStore, len(stores))}
+	auth, _ := NewServerAuthorizerWithOptions(stores)
+	return auth
+}
+
+// NewServerAuthorizerWithOptions returns a new ServerAuthorizer with the given token stores and options.
+// The key of the map stores is the authentication schema.
+func NewServerAuthorizerWithOptions(stores map[string]TokenStore, opts ...ServerAuthorizerOption) (*ServerAuthorizer, error) {
+	auth := &ServerAuthorizer{stores: make(map[string]TokenStore, len(stores)), logSubject: true}
 	for schema, store := range stores {
 		auth.stores[strings.ToLower(schema)] = store
 	}
-	return auth
+	for _, opt := range opts {
+		if err := opt(auth); err != nil {
+			return nil, err
+		}
+	}
+	return auth, nil
 }
 
 // resolveIdentity resolves the identity from the context.
 func (auth *ServerAuthorizer) resolveIdentity(ctx context.Context) (*Identity, error) {
-	ahv := metadata.ExtractIncoming(ctx).Get(AuthHeaderKey)
+	return auth.resolveIdentityFromHeader(ctx, metadata.ExtractIncoming(ctx).Get(AuthHeaderKey))
+}
+
+// resolveIdentityFromHeader resolves the identity from the value of authorization header.
+func (auth *ServerAuthorizer) resolveIdentityFromHeader(ctx context.Context, ahv string) (*Identity, error) {
 	if splits := strings.SplitN(ahv, " ", 2); len(splits) == 2 {
 		schema := strings.ToLower(splits[0])
 		if store, ok := auth.stores[schema]; ok {
 			if t, err := store.Verify(ctx, splits[1]); err == nil {
-				return &Identity{schema: schema, token: t}, nil
+				return auth.withPermissions(ctx, &Identity{schema: schema, token: t}), nil
 			} else if errors.Is(err, jwt.ErrTokenExpired) {
 				return nil, ErrExpiredToken
 			} else {
@@ -51,13 +77,45 @@ func (auth *ServerAuthorizer) resolveIdentity(ctx context.Context) (*Identity, e
 	return nil, nil
 }
 
+// EnforcesPolicies reports whether methods without policies are denied,
+// that is if there is a policy table, or if proto options are enforced in strict mode.
+func (auth *ServerAuthorizer) EnforcesPolicies() bool {
+	return auth.policies != nil || (auth.options != nil && auth.options.strict)
+}
+
+// authorizeMethod authorizes the identity in the given context to call the given method,
+// with policies of the policy table or declared by proto options.
+// Methods without policies are denied if there is a policy table, or if proto options are enforced in strict mode.
+func (auth *ServerAuthorizer) authorizeMethod(ctx context.Context, method string) error {
+	if auth.policies != nil {
+		if funcs, ok := auth.policies.Lookup(method); ok {
+			return Authorize(ctx, funcs...)
+		}
+	}
+	if auth.options != nil {
+		if funcs, ok := auth.options.rules[method]; ok {
+			return Authorize(ctx, funcs...)
+		}
+	}
+	if auth.EnforcesPolicies() {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
 // UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that authorizes the identity in the context.
 func (auth *ServerAuthorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		if user, err := auth.resolveIdentity(ctx); err != nil {
 			return nil, err
 		} else if user != nil {
-			ctx = context.WithValue(ctx, identityKey{}, user)
+			ctx = contextWithIdentity(ctx, user, auth.logSubject)
+			auth.telemetry.record(ctx, user, info.FullMethod)
+		}
+		// the request is decoded before interceptors, so it is available to authorization expressions
+		ctx = contextWithCall(ctx, info.FullMethod, req)
+		if err := auth.authorizeMethod(ctx, info.FullMethod); err != nil {
+			return nil, err
 		}
 		if authorizer, ok := info.Server.(authorizer); ok {
 			if err := authorizer.Authorize(ctx, info.FullMethod); err != nil {
@@ -71,10 +129,17 @@ func (auth *ServerAuthorizer) UnaryServerInterceptor() grpc.UnaryServerIntercept
 // StreamServerInterceptor returns a grpc.StreamServerInterceptor that authorizes the identity in the context.
 func (auth *ServerAuthorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
 	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		if user, err := auth.resolveIdentity(ss.Context()); err != nil {
+		ctx := ss.Context()
+		if user, err := auth.resolveIdentity(ctx); err != nil {
 			return err
 		} else if user != nil {
-			ss = &middleware.WrappedServerStream{ServerStream: ss, WrappedContext: context.WithValue(ss.Context(), identityKey{}, user)}
+			ctx = contextWithIdentity(ctx, user, auth.logSubject)
+			auth.telemetry.record(ctx, user, info.FullMethod)
+		}
+		// messages of streaming calls are received by handlers, so there is no request for authorization expressions
+		ss = &middleware.WrappedServerStream{ServerStream: ss, WrappedContext: contextWithCall(ctx, info.FullMethod, nil)}
+		if err := auth.authorizeMethod(ss.Context(), info.FullMethod); err != nil {
+			return err
 		}
 		if authorizer, ok := srv.(authorizer); ok {
 			if err := authorizer.Authorize(ss.Context(), info.FullMethod); err != nil {
@@ -0,0 +1,22 @@
+package secure
+
+import (
+	"context"
+	"net/http"
+)
+
+// AuthorizeRequest resolves the identity from the authorization header of the given http request,
+// and authorizes it with the given auth functions.
+// It returns the request context carrying the identity, used by handlers registered outside grpc services.
+func (auth *ServerAuthorizer) AuthorizeRequest(r *http.Request, funcs ...AuthFunc) (context.Context, error) {
+	ctx := r.Context()
+	if user, err := auth.resolveIdentityFromHeader(ctx, r.Header.Get(AuthHeaderKey)); err != nil {
+		return nil, err
+	} else if user != nil {
+		ctx = contextWithIdentity(ctx, user, auth.logSubject)
+	}
+	if err := Authorize(ctx, funcs...); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
@@ -1,9 +1,13 @@
 package secure
 
+import "slices"
+
 // Identity represents the result of authentication.
 type Identity struct {
-	schema string
-	token  *Token
+	schema      string
+	token       *Token
+	permissions *identityPermissions // resolved by PermissionResolver
+	call        *callInfo            // the grpc call being authorized, set by Authorize
 }
 
 // NewIdentity returns a new Identity with the given schema and token.
@@ -23,3 +27,17 @@ func (i *Identity) Schema() string {
 func (i *Identity) Token() *Token {
 	return i.token
 }
+
+// Permissions returns a copy of the permissions of the identity resolved by PermissionResolver,
+// they are resolved when first required, and nil if they can not be resolved.
+func (i *Identity) Permissions() []string {
+	permissions, _ := i.permissions.get()
+	return slices.Clone(permissions)
+}
+
+// HasPermission returns whether the identity has the given permission, see MatchPermission.
+// It returns false if the permissions can not be resolved.
+func (i *Identity) HasPermission(permission string) bool {
+	permissions, _ := i.permissions.get()
+	return matchAnyPermission(permissions, permission)
+}
@@ -0,0 +1,100 @@
+package secure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/choral-io/gommerce-server-core/config"
+	"github.com/choral-io/gommerce-server-core/secure/authpb"
+)
+
+// methodOptions is policies of methods declared by the (gommerce.auth) and (gommerce.service_auth) proto options.
+type methodOptions struct {
+	strict bool
+	rules  map[string][]AuthFunc // by full method name
+}
+
+// WithMethodOptions returns a ServerAuthorizerOption that authorizes calls with policies declared by proto options,
+// such as option (gommerce.auth) = {scopes: ["orders.write"]} of methods, or (gommerce.service_auth) of services.
+// Policies are read from services given to RegisterServices, methods without policies are not authorized by them.
+// In strict mode, RegisterServices fails for methods without policies, and calls of unknown methods are denied.
+func WithMethodOptions(strict bool) ServerAuthorizerOption {
+	return func(auth *ServerAuthorizer) error {
+		auth.options = &methodOptions{strict: strict, rules: make(map[string][]AuthFunc)}
+		return nil
+	}
+}
+
+// WithMethodOptionsConfig returns a ServerAuthorizerOption like WithMethodOptions, in strict mode if it is enabled by the config.
+func WithMethodOptionsConfig(cfg config.SecureConfig) ServerAuthorizerOption {
+	return WithMethodOptions(cfg.GetStrict())
+}
+
+// WithExpressionOptions returns a ServerAuthorizerOption that compiles authorization expressions declared by proto options
+// with the given options, such as custom functions added by expr.Function.
+func WithExpressionOptions(opts ...expr.Option) ServerAuthorizerOption {
+	return func(auth *ServerAuthorizer) error {
+		auth.exprOpts = append(auth.exprOpts, opts...)
+		return nil
+	}
+}
+
+// RegisterServices reads policies declared by proto options of the given services from protoregistry.GlobalFiles,
+// the services are returned by grpc.Server.GetServiceInfo. It must be called before serving, and does nothing
+// if WithMethodOptions is not used. Methods matched by the policy table of WithPolicies are not required to declare policies,
+// such as methods of the health and reflection services in strict mode.
+func (auth *ServerAuthorizer) RegisterServices(services map[string]grpc.ServiceInfo) error {
+	if auth.options == nil {
+		return nil
+	}
+	var undeclared []string
+	for name, info := range services {
+		// services without descriptors in the registry have no policies declared by proto options
+		desc, _ := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		sd, _ := desc.(protoreflect.ServiceDescriptor)
+		for _, m := range info.Methods {
+			method := "/" + name + "/" + m.Name
+			rule := methodAuthRule(sd, m.Name)
+			if rule == nil {
+				if auth.policies != nil {
+					if _, ok := auth.policies.Lookup(method); ok {
+						continue
+					}
+				}
+				undeclared = append(undeclared, method)
+				continue
+			}
+			funcs, err := policyAuthFuncs(rule, auth.exprOpts...)
+			if err != nil {
+				return fmt.Errorf("invalid policy of %s: %w", method, err)
+			}
+			auth.options.rules[method] = funcs
+		}
+	}
+	if auth.options.strict && len(undeclared) > 0 {
+		return fmt.Errorf("methods without policies: %s", strings.Join(undeclared, ", "))
+	}
+	return nil
+}
+
+// methodAuthRule returns the policy of the given method of the service, or of the service if the method has none.
+func methodAuthRule(sd protoreflect.ServiceDescriptor, method string) *authpb.AuthRule {
+	if sd == nil {
+		return nil
+	}
+	if md := sd.Methods().ByName(protoreflect.Name(method)); md != nil {
+		if opts := md.Options(); opts != nil && proto.HasExtension(opts, authpb.E_Auth) {
+			return proto.GetExtension(opts, authpb.E_Auth).(*authpb.AuthRule)
+		}
+	}
+	if opts := sd.Options(); opts != nil && proto.HasExtension(opts, authpb.E_ServiceAuth) {
+		return proto.GetExtension(opts, authpb.E_ServiceAuth).(*authpb.AuthRule)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package secure
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/choral-io/gommerce-server-core/logging"
+)
+
+// PermissionResolver resolves permissions of subjects, such as permissions granted by roles bound to them.
+type PermissionResolver interface {
+	// ResolvePermissions returns permissions of the given subject in the given realm.
+	ResolvePermissions(ctx context.Context, realm, subject string) ([]string, error)
+}
+
+// WithPermissionResolver returns a ServerAuthorizerOption that resolves permissions of identities with the given resolver,
+// they are required by AuthFuncRequirePermission.
+func WithPermissionResolver(resolver PermissionResolver) ServerAuthorizerOption {
+	return func(auth *ServerAuthorizer) error {
+		auth.resolver = resolver
+		return nil
+	}
+}
+
+// identityPermissions is the permissions of an identity, resolved once when they are first required.
+type identityPermissions struct {
+	once        sync.Once
+	resolve     func() ([]string, error)
+	permissions []string
+	err         error
+}
+
+// get returns the resolved permissions, or nil if there is no PermissionResolver.
+func (p *identityPermissions) get() ([]string, error) {
+	if p == nil {
+		return nil, nil
+	}
+	p.once.Do(func() {
+		p.permissions, p.err = p.resolve()
+	})
+	return p.permissions, p.err
+}
+
+// withPermissions sets the permissions of the given identity, resolved lazily by the PermissionResolver if there is one,
+// so calls not requiring permissions do not depend on the resolver.
+func (auth *ServerAuthorizer) withPermissions(ctx context.Context, user *Identity) *Identity {
+	if auth.resolver != nil && user.token != nil && user.token.Subject() != "" {
+		resolver, realm, subject := auth.resolver, user.token.Realm(), user.token.Subject()
+		user.permissions = &identityPermissions{resolve: func() ([]string, error) {
+			permissions, err := resolver.ResolvePermissions(ctx, realm, subject)
+			if err != nil {
+				// the error is not returned to callers, as it may expose details of the store
+				logging.DefaultLogger().Error(ctx, "failed to resolve permissions", "error", err, "realm", realm)
+			}
+			return permissions, err
+		}}
+	}
+	return user
+}
+
+// AuthFuncRequirePermission returns an AuthFunc that requires the identity has the given permission,
+// permissions are resolved by the PermissionResolver of ServerAuthorizer, see WithPermissionResolver.
+// It returns ErrPermissionResolution if the permissions can not be resolved.
+func AuthFuncRequirePermission(permission string) AuthFunc {
+	return func(user *Identity) error {
+		if user.token == nil || user.token.Subject() == "" {
+			return ErrUnauthenticated
+		}
+		permissions, err := user.permissions.get()
+		if err != nil {
+			return ErrPermissionResolution
+		}
+		if !matchAnyPermission(permissions, permission) {
+			return ErrPermissionDenied
+		}
+		return nil
+	}
+}
+
+func matchAnyPermission(granted []string, required string) bool {
+	for _, g := range granted {
+		if MatchPermission(g, required) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPermission returns whether the granted permission matches the required permission.
+// Permissions are matched by segments separated by ":", "*" matches any segment,
+// and a trailing "*" matches any remaining segments, such as "orders:*" matches "orders:refund" and "orders:items:read".
+func MatchPermission(granted, required string) bool {
+	gs := strings.Split(granted, ":")
+	rs := strings.Split(required, ":")
+	for i, g := range gs {
+		if g == "*" && i == len(gs)-1 {
+			return true
+		}
+		if i >= len(rs) || (g != "*" && !strings.EqualFold(g, rs[i])) {
+			return false
+		}
+	}
+	return len(gs) == len(rs)
+}
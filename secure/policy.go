@@ -0,0 +1,126 @@
+package secure
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+// PolicyTable maps full method names to auth functions authorizing calls of the methods.
+// The auth functions of the first exact match are used, or of the first matched glob pattern in the order they are added.
+// Methods not matched by any policy are denied.
+type PolicyTable struct {
+	exact    map[string][]AuthFunc
+	patterns []policyPattern
+}
+
+type policyPattern struct {
+	pattern string
+	funcs   []AuthFunc
+}
+
+// NewPolicyTable returns a new PolicyTable with the given policy configs,
+// expressions of the policies are compiled once here with the given options, such as custom functions added by expr.Function.
+func NewPolicyTable(cfgs []config.SecurePolicyConfig, opts ...expr.Option) (*PolicyTable, error) {
+	t := &PolicyTable{exact: make(map[string][]AuthFunc)}
+	for _, cfg := range cfgs {
+		funcs, err := policyAuthFuncs(cfg, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy of %s: %w", cfg.GetMethod(), err)
+		}
+		if err := t.Add(cfg.GetMethod(), funcs...); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// Add adds a policy authorizing calls of methods matched by the given full method name or glob pattern,
+// such as "/pkg.Service/Method" or "/pkg.Service/*", see path.Match for the syntax of patterns.
+// Calls are authorized with the given auth functions, the methods are public if there is none.
+func (t *PolicyTable) Add(method string, funcs ...AuthFunc) error {
+	if _, err := path.Match(method, ""); err != nil {
+		return fmt.Errorf("invalid policy method %q: %w", method, err)
+	}
+	if !hasGlobMeta(method) {
+		if _, ok := t.exact[method]; !ok {
+			t.exact[method] = funcs
+		}
+		return nil
+	}
+	t.patterns = append(t.patterns, policyPattern{pattern: method, funcs: funcs})
+	return nil
+}
+
+// Lookup returns the auth functions of the given full method name, and whether the method is matched by any policy.
+func (t *PolicyTable) Lookup(method string) ([]AuthFunc, bool) {
+	if funcs, ok := t.exact[method]; ok {
+		return funcs, true
+	}
+	for _, p := range t.patterns {
+		if ok, _ := path.Match(p.pattern, method); ok {
+			return p.funcs, true
+		}
+	}
+	return nil, false
+}
+
+// WithPolicies returns a ServerAuthorizerOption that authorizes calls with the given policies before the handler,
+// they take precedence over policies declared by proto options, methods without any policy are denied.
+func WithPolicies(t *PolicyTable) ServerAuthorizerOption {
+	return func(auth *ServerAuthorizer) error {
+		auth.policies = t
+		return nil
+	}
+}
+
+// policyRule is the requirements of a policy, implemented by config.SecurePolicyConfig and authpb.AuthRule.
+type policyRule interface {
+	GetPublic() bool
+	GetSchema() string
+	GetRealm() string
+	GetScopes() []string
+	GetPermissions() []string
+	GetExpression() string
+}
+
+// policyAuthFuncs returns auth functions of the given policy rule, rules other than public require authenticated identities.
+func policyAuthFuncs(cfg policyRule, opts ...expr.Option) ([]AuthFunc, error) {
+	if cfg.GetPublic() {
+		return nil, nil
+	}
+	funcs := []AuthFunc{AuthFuncAuthenticated}
+	if schema := cfg.GetSchema(); schema != "" {
+		funcs = append(funcs, AuthFuncRequireSchema(schema))
+	}
+	if realm := cfg.GetRealm(); realm != "" {
+		funcs = append(funcs, AuthFuncRequireRealm(realm))
+	}
+	for _, scope := range cfg.GetScopes() {
+		funcs = append(funcs, AuthFuncRequireScope(scope))
+	}
+	for _, permission := range cfg.GetPermissions() {
+		funcs = append(funcs, AuthFuncRequirePermission(permission))
+	}
+	if script := cfg.GetExpression(); script != "" {
+		f, err := compileAuthFuncExpression(script, opts...)
+		if err != nil {
+			return nil, err
+		}
+		funcs = append(funcs, f)
+	}
+	return funcs, nil
+}
+
+func hasGlobMeta(pattern string) bool {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*', '?', '[', '\\':
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Package rbac resolves permissions of subjects from roles bound to them, for secure.AuthFuncRequirePermission.
+// Roles inherit permissions from their parents, and subjects are bound to roles per realm.
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+// Role is a named set of permissions in a realm.
+type Role struct {
+	bun.BaseModel `bun:"table:rbac_roles" json:"-"`
+
+	Realm       string   `bun:"realm,pk" json:"realm"`
+	Name        string   `bun:"name,pk" json:"name"`
+	Parents     []string `bun:"parents" json:"parents,omitempty"` // names of roles that the role inherits permissions from
+	Permissions []string `bun:"permissions" json:"permissions,omitempty"`
+}
+
+// Binding binds a subject to a role in a realm.
+type Binding struct {
+	bun.BaseModel `bun:"table:rbac_bindings" json:"-"`
+
+	Realm   string `bun:"realm,pk" json:"realm"`
+	Subject string `bun:"subject,pk" json:"subject"`
+	Role    string `bun:"role,pk" json:"role"`
+}
+
+// Store stores roles and bindings.
+type Store interface {
+	// Roles returns roles of the given realm.
+	Roles(ctx context.Context, realm string) ([]*Role, error)
+	// BoundRoles returns names of roles bound to the given subject in the given realm.
+	BoundRoles(ctx context.Context, realm, subject string) ([]string, error)
+}
+
+// NewStore returns a new Store with the given config.
+// There are two types of stores: config and bun, bun.IDB is required if the type of store is bun.
+func NewStore(cfg config.SecureRBACConfig, db bun.IDB) (Store, error) {
+	switch cfg.GetStore() {
+	case "config":
+		return NewConfigStore(cfg.GetRealms()), nil
+	case "bun":
+		if db == nil {
+			return nil, errors.New("bun db is required for bun rbac store")
+		}
+		return NewBunStore(db), nil
+	}
+	return nil, fmt.Errorf("unknown rbac store: %s", cfg.GetStore())
+}
+
+// ConfigStore is a Store with roles and bindings read from config.
+type ConfigStore struct {
+	roles    map[string][]*Role             // by realm
+	bindings map[string]map[string][]string // by realm and subject
+}
+
+// NewConfigStore returns a new ConfigStore with the given realm configs.
+func NewConfigStore(cfgs []config.SecureRBACRealmConfig) *ConfigStore {
+	s := &ConfigStore{roles: make(map[string][]*Role), bindings: make(map[string]map[string][]string)}
+	for _, realm := range cfgs {
+		for _, role := range realm.GetRoles() {
+			s.roles[realm.GetName()] = append(s.roles[realm.GetName()], &Role{
+				Realm:       realm.GetName(),
+				Name:        role.GetName(),
+				Parents:     role.GetParents(),
+				Permissions: role.GetPermissions(),
+			})
+		}
+		bindings := make(map[string][]string)
+		for _, binding := range realm.GetBindings() {
+			bindings[binding.GetSubject()] = append(bindings[binding.GetSubject()], binding.GetRoles()...)
+		}
+		s.bindings[realm.GetName()] = bindings
+	}
+	return s
+}
+
+func (s *ConfigStore) Roles(_ context.Context, realm string) ([]*Role, error) {
+	return s.roles[realm], nil
+}
+
+func (s *ConfigStore) BoundRoles(_ context.Context, realm, subject string) ([]string, error) {
+	return s.bindings[realm][subject], nil
+}
+
+// BunStore is a Store with roles and bindings stored in the rbac_roles and rbac_bindings tables.
+// Changes of the tables are not visible to cached resolvers until they are invalidated, see PublishInvalidation.
+type BunStore struct {
+	db bun.IDB
+}
+
+// NewBunStore returns a new BunStore with the given db.
+func NewBunStore(db bun.IDB) *BunStore {
+	return &BunStore{db: db}
+}
+
+// CreateTables creates the tables of roles and bindings if they do not exist.
+func (s *BunStore) CreateTables(ctx context.Context) error {
+	for _, model := range []any{(*Role)(nil), (*Binding)(nil)} {
+		if _, err := s.db.NewCreateTable().Model(model).IfNotExists().Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BunStore) Roles(ctx context.Context, realm string) ([]*Role, error) {
+	var roles []*Role
+	err := s.db.NewSelect().Model(&roles).Where("realm = ?", realm).Scan(ctx)
+	return roles, err
+}
+
+func (s *BunStore) BoundRoles(ctx context.Context, realm, subject string) ([]string, error) {
+	var roles []string
+	err := s.db.NewSelect().Model((*Binding)(nil)).Column("role").
+		Where("realm = ?", realm).Where("subject = ?", subject).Scan(ctx, &roles)
+	return roles, err
+}
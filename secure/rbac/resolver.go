@@ -0,0 +1,187 @@
+package rbac
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/choral-io/gommerce-server-core/config"
+	"github.com/choral-io/gommerce-server-core/events"
+	"github.com/choral-io/gommerce-server-core/secure"
+)
+
+// Resolver is a secure.PermissionResolver resolving permissions from roles of a Store, including inherited ones.
+// Roles and resolved permissions are cached until they expire or are invalidated.
+type Resolver struct {
+	store Store
+	ttl   time.Duration
+
+	mu          sync.Mutex
+	generation  uint64                     // increased by invalidations, so results loaded before them are not cached
+	roles       map[string]*rolesEntry     // by realm
+	permissions map[subjectKey]*permsEntry // by realm and subject
+}
+
+var _ secure.PermissionResolver = (*Resolver)(nil)
+
+type subjectKey struct {
+	realm   string
+	subject string
+}
+
+type rolesEntry struct {
+	roles     map[string]*Role // by name
+	expiresAt time.Time
+}
+
+type permsEntry struct {
+	permissions []string
+	expiresAt   time.Time
+}
+
+// NewResolver returns a new Resolver with the given store, results are cached for the given ttl.
+func NewResolver(store Store, ttl time.Duration) *Resolver {
+	return &Resolver{
+		store:       store,
+		ttl:         ttl,
+		roles:       make(map[string]*rolesEntry),
+		permissions: make(map[subjectKey]*permsEntry),
+	}
+}
+
+// NewResolverWithConfig returns a new Resolver with the given config and store, results are cached for the configured ttl.
+// If nc is not nil, it subscribes to invalidation messages published to the configured subject until nc is closed.
+func NewResolverWithConfig(cfg config.SecureRBACConfig, store Store, nc *nats.Conn) (*Resolver, error) {
+	r := NewResolver(store, cfg.GetCacheTTL())
+	if nc != nil {
+		if _, err := r.Subscribe(nc, cfg.GetSubject()); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *Resolver) ResolvePermissions(ctx context.Context, realm, subject string) ([]string, error) {
+	key := subjectKey{realm: realm, subject: subject}
+	now := time.Now()
+	r.mu.Lock()
+	generation := r.generation
+	if e, ok := r.permissions[key]; ok && now.Before(e.expiresAt) {
+		r.mu.Unlock()
+		return e.permissions, nil
+	}
+	r.mu.Unlock()
+
+	roles, err := r.realmRoles(ctx, realm, generation)
+	if err != nil {
+		return nil, err
+	}
+	bound, err := r.store.BoundRoles(ctx, realm, subject)
+	if err != nil {
+		return nil, err
+	}
+	permissions := expandPermissions(roles, bound)
+
+	r.mu.Lock()
+	if r.generation == generation {
+		r.permissions[key] = &permsEntry{permissions: permissions, expiresAt: now.Add(r.ttl)}
+	}
+	r.mu.Unlock()
+	return permissions, nil
+}
+
+// realmRoles returns roles of the given realm by name, from the cache or the store.
+func (r *Resolver) realmRoles(ctx context.Context, realm string, generation uint64) (map[string]*Role, error) {
+	now := time.Now()
+	r.mu.Lock()
+	if e, ok := r.roles[realm]; ok && now.Before(e.expiresAt) {
+		r.mu.Unlock()
+		return e.roles, nil
+	}
+	r.mu.Unlock()
+
+	list, err := r.store.Roles(ctx, realm)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[string]*Role, len(list))
+	for _, role := range list {
+		roles[role.Name] = role
+	}
+
+	r.mu.Lock()
+	if r.generation == generation {
+		r.roles[realm] = &rolesEntry{roles: roles, expiresAt: now.Add(r.ttl)}
+	}
+	r.mu.Unlock()
+	return roles, nil
+}
+
+// Invalidate removes cached roles and permissions of the given realm, or of all realms if realm is empty.
+func (r *Resolver) Invalidate(realm string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generation++
+	if realm == "" {
+		r.roles = make(map[string]*rolesEntry)
+		r.permissions = make(map[subjectKey]*permsEntry)
+		return
+	}
+	delete(r.roles, realm)
+	for key := range r.permissions {
+		if key.realm == realm {
+			delete(r.permissions, key)
+		}
+	}
+}
+
+// Subscribe subscribes to invalidation messages published to the given subject by PublishInvalidation,
+// so changes of roles and bindings are visible to all instances before the cache expires.
+func (r *Resolver) Subscribe(nc *nats.Conn, subject string) (*nats.Subscription, error) {
+	return nc.Subscribe(subject, func(msg *nats.Msg) {
+		r.Invalidate(string(msg.Data))
+	})
+}
+
+// PublishInvalidation publishes an invalidation message of the given realm to the given subject,
+// or of all realms if realm is empty. It should be published after roles or bindings are changed.
+func PublishInvalidation(ctx context.Context, nc *nats.Conn, subject, realm string) error {
+	msg := nats.NewMsg(subject)
+	msg.Data = []byte(realm)
+	events.InjectTraceContext(ctx, msg)
+	return nc.PublishMsg(msg)
+}
+
+// expandPermissions returns permissions of the given bound roles and the roles they inherit from, without duplicates.
+// Unknown roles are ignored, and cycles of inheritance are broken.
+func expandPermissions(roles map[string]*Role, bound []string) []string {
+	visited := make(map[string]bool)
+	seen := make(map[string]bool)
+	permissions := make([]string, 0)
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		role, ok := roles[name]
+		if !ok {
+			return
+		}
+		for _, p := range role.Permissions {
+			if !seen[p] {
+				seen[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+		for _, parent := range role.Parents {
+			visit(parent)
+		}
+	}
+	for _, name := range bound {
+		visit(name)
+	}
+	return permissions
+}
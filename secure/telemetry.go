@@ -0,0 +1,95 @@
+package secure
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/choral-io/gommerce-server-core/config"
+)
+
+const (
+	instrumentationName = "github.com/choral-io/gommerce-server-core/secure"
+
+	AttrAuthSchema = attribute.Key("auth.schema")
+	AttrAuthRealm  = attribute.Key("auth.realm")
+	AttrAuthClient = attribute.Key("auth.client")
+)
+
+// identityTelemetry records identities resolved by ServerAuthorizer on spans and metrics.
+type identityTelemetry struct {
+	spanAttributes bool
+	spanSubject    bool
+	callCounter    metric.Int64Counter // nil if metric attributes are disabled
+}
+
+// WithTelemetry returns a ServerAuthorizerOption that records resolved identities on spans and metrics.
+// The given MeterProvider is only used if metric attributes are enabled.
+// Whether the subject is added to logging contexts is set by the config as well, see WithLogSubject.
+func WithTelemetry(cfg config.SecureTelemetryConfig, mp metric.MeterProvider) ServerAuthorizerOption {
+	return func(auth *ServerAuthorizer) error {
+		t := &identityTelemetry{
+			spanAttributes: cfg.GetSpanAttributes(),
+			spanSubject:    cfg.GetSpanSubject(),
+		}
+		if cfg.GetMetricAttributes() {
+			counter, err := mp.Meter(instrumentationName).Int64Counter("rpc.server.identity.calls",
+				metric.WithUnit("{call}"),
+				metric.WithDescription("Number of calls made by resolved identities, by schema, realm and client."),
+			)
+			if err != nil {
+				return err
+			}
+			t.callCounter = counter
+		}
+		auth.telemetry = t
+		auth.logSubject = cfg.GetLogSubject()
+		return nil
+	}
+}
+
+// record records the given identity on the active span of ctx, and counts the call.
+func (t *identityTelemetry) record(ctx context.Context, user *Identity, method string) {
+	if t == nil || user == nil || user.token == nil {
+		return
+	}
+	if span := trace.SpanFromContext(ctx); span.IsRecording() && (t.spanAttributes || t.spanSubject) {
+		attrs := make([]attribute.KeyValue, 0, 5)
+		if t.spanAttributes {
+			attrs = append(attrs,
+				AttrAuthSchema.String(user.schema),
+				AttrAuthRealm.String(user.token.realm),
+				AttrAuthClient.String(user.token.client),
+				semconv.EnduserScope(strings.Join(user.token.scope, " ")),
+			)
+		}
+		if t.spanSubject {
+			attrs = append(attrs, semconv.EnduserID(user.token.subject))
+		}
+		span.SetAttributes(attrs...)
+	}
+	if t.callCounter != nil {
+		service, name := splitFullMethod(method)
+		// the subject is never used as a metric attribute, it is unbounded and privacy-sensitive
+		t.callCounter.Add(ctx, 1, metric.WithAttributes(
+			AttrAuthSchema.String(user.schema),
+			AttrAuthRealm.String(user.token.realm),
+			AttrAuthClient.String(user.token.client),
+			semconv.RPCService(service),
+			semconv.RPCMethod(name),
+		))
+	}
+}
+
+// splitFullMethod splits the full method name "/pkg.Service/Method" into the service and method names, like otelgrpc.
+func splitFullMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimLeft(fullMethod, "/"), "/")
+	if !ok {
+		return "", fullMethod
+	}
+	return service, method
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// gatewayErrorHandler sets the Retry-After header for errors with RetryInfo details,
+// then writes the error with runtime.DefaultHTTPErrorHandler, which maps grpc codes to http status.
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if st, ok := status.FromError(err); ok {
+		for _, detail := range st.Details() {
+			if ri, ok := detail.(*errdetails.RetryInfo); ok && ri.GetRetryDelay() != nil {
+				seconds := int(math.Ceil(ri.GetRetryDelay().AsDuration().Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+				break
+			}
+		}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
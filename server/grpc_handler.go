@@ -12,6 +12,7 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/rs/cors"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
@@ -22,6 +23,7 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/choral-io/gommerce-server-core/audit"
 	"github.com/choral-io/gommerce-server-core/config"
 	"github.com/choral-io/gommerce-server-core/logging"
 	"github.com/choral-io/gommerce-server-core/secure"
@@ -58,8 +60,10 @@ type GRPCHandler struct {
 	srvServers []ServerServiceRegisterFunc // grpc server services
 	gtwClients []GatewayClientRegisterFunc // grpc gateway clients
 
-	useHealthz bool         // whether to use healthz endpoint
-	rsCorsOpts cors.Options // cors options
+	authorizer *secure.ServerAuthorizer      // authorizer of the secure interceptor
+	useHealthz bool                          // whether to use healthz endpoint
+	rsCorsOpts cors.Options                  // cors options
+	propagator propagation.TextMapPropagator // propagator for gateway requests
 }
 
 // GRPCHandlerOption is an option for GRPCHandler, used to configure it.
@@ -69,7 +73,7 @@ type GRPCHandlerOption func(*GRPCHandler) error
 func NewGRPCHandler(cfg config.ServerHTTPConfig, opts ...GRPCHandlerOption) (*GRPCHandler, error) {
 	h := &GRPCHandler{
 		srvOptions: []grpc.ServerOption{},
-		gtwOptions: []runtime.ServeMuxOption{},
+		gtwOptions: []runtime.ServeMuxOption{runtime.WithErrorHandler(gatewayErrorHandler)},
 		unaryInts:  []grpc.UnaryServerInterceptor{},
 		streamInts: []grpc.StreamServerInterceptor{},
 		gcdOptions: []grpc.DialOption{
@@ -113,7 +117,21 @@ func NewGRPCHandler(cfg config.ServerHTTPConfig, opts ...GRPCHandlerOption) (*GR
 	}
 
 	reflection.Register(grpcServer)
-	gtwHandler := cors.New(h.rsCorsOpts).Handler(gatewayMux)
+	if h.authorizer != nil {
+		// policies declared by proto options are read from registered services, it fails fast in strict mode
+		if err := h.authorizer.RegisterServices(grpcServer.GetServiceInfo()); err != nil {
+			return nil, err
+		}
+	}
+	var gtwHandler http.Handler = gatewayMux
+	if h.propagator != nil {
+		// extract trace context from gateway requests, it is injected into grpc calls by the client stats handler
+		gtwHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := h.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			gatewayMux.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+	gtwHandler = cors.New(h.rsCorsOpts).Handler(gtwHandler)
 
 	h.h2cHandler = h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
@@ -140,9 +158,13 @@ func WithCorsOptions(opts cors.Options) GRPCHandlerOption {
 }
 
 // WithOTELStatsHandler returns a GRPCHandlerOption that use an opentelemetry stats handler for grpc server.
+// The global TextMapPropagator is used for grpc server, gateway and client calls,
+// it is resolved per call, so it may be set after the option is created.
+// TraceContext and Baggage are propagated if no global TextMapPropagator is set.
 func WithOTELStatsHandler(tp trace.TracerProvider, mp metric.MeterProvider) GRPCHandlerOption {
-	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	var propagator propagation.TextMapPropagator = globalPropagator{}
 	return func(h *GRPCHandler) error {
+		h.propagator = propagator
 		// otelgrpc.UnaryServerInterceptor and otelgrpc.StreamServerInterceptor are deprecated,
 		// Use otelgrpc.NewServerHandler instead
 		h.srvOptions = append(h.srvOptions, grpc.StatsHandler(otelgrpc.NewServerHandler(
@@ -159,6 +181,32 @@ func WithOTELStatsHandler(tp trace.TracerProvider, mp metric.MeterProvider) GRPC
 	}
 }
 
+// defaultPropagator is used if no global TextMapPropagator is set, such as by otel.NewTracerProvider.
+var defaultPropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// globalPropagator is a TextMapPropagator that delegates to the global TextMapPropagator at the time of each call,
+// or to defaultPropagator if the global one is not set, which propagates no fields.
+type globalPropagator struct{}
+
+func (globalPropagator) propagator() propagation.TextMapPropagator {
+	if p := otel.GetTextMapPropagator(); len(p.Fields()) > 0 {
+		return p
+	}
+	return defaultPropagator
+}
+
+func (g globalPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	g.propagator().Inject(ctx, carrier)
+}
+
+func (g globalPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return g.propagator().Extract(ctx, carrier)
+}
+
+func (g globalPropagator) Fields() []string {
+	return g.propagator().Fields()
+}
+
 // WithStaticFileHandler returns a GRPCHandlerOption that adds a static file handler to grpc gateway.
 func WithStaticFileHandler(pattern string, sfs fs.FS) GRPCHandlerOption {
 	hfs := http.FileServer(http.FS(sfs))
@@ -216,8 +264,9 @@ func WithStreamInterceptors(ints ...grpc.StreamServerInterceptor) GRPCHandlerOpt
 }
 
 // WithLoggingInterceptor returns a GRPCHandlerOption that adds a logging interceptor to grpc handler.
-func WithLoggingInterceptor(logger logging.Logger) GRPCHandlerOption {
-	grpclog := logging.NewGRPCLogger(logger)
+// The given options configure the interceptor, for example logging.WithPayloadLogging.
+func WithLoggingInterceptor(logger logging.Logger, opts ...logging.GRPCLoggerOption) GRPCHandlerOption {
+	grpclog := logging.NewGRPCLogger(logger, opts...)
 	return func(h *GRPCHandler) error {
 		h.unaryInts = append(h.unaryInts, grpclog.UnaryServerInterceptor())
 		h.streamInts = append(h.streamInts, grpclog.StreamServerInterceptor())
@@ -245,9 +294,14 @@ func WithValidatorInterceptor() GRPCHandlerOption {
 }
 
 // WithSecureInterceptor returns a GRPCHandlerOption that adds a secure interceptor to grpc handler.
-// The given matcher will be used to determine which methods should be secured.
+// The given matcher will be used to determine which methods should be secured, all methods are secured if matcher is nil.
+// A matcher is rejected if the authorizer enforces policies, as unmatched methods would bypass them.
 func WithSecureInterceptor(auth *secure.ServerAuthorizer, matcher selector.Matcher) GRPCHandlerOption {
 	return func(h *GRPCHandler) error {
+		if matcher != nil && auth.EnforcesPolicies() {
+			return errors.New("secure interceptor with a matcher cannot be used with an authorizer enforcing policies")
+		}
+		h.authorizer = auth
 		if matcher == nil {
 			h.unaryInts = append(h.unaryInts, auth.UnaryServerInterceptor())
 			h.streamInts = append(h.streamInts, auth.StreamServerInterceptor())
@@ -259,6 +313,22 @@ func WithSecureInterceptor(auth *secure.ServerAuthorizer, matcher selector.Match
 	}
 }
 
+// WithAuditInterceptor returns a GRPCHandlerOption that records audit records of calls selected by the given matcher,
+// all calls are recorded if matcher is nil.
+// It should be registered before WithSecureInterceptor, so denied calls are recorded with the resolved identity.
+func WithAuditInterceptor(auditor *audit.Auditor, matcher selector.Matcher) GRPCHandlerOption {
+	return func(h *GRPCHandler) error {
+		if matcher == nil {
+			h.unaryInts = append(h.unaryInts, auditor.UnaryServerInterceptor())
+			h.streamInts = append(h.streamInts, auditor.StreamServerInterceptor())
+		} else {
+			h.unaryInts = append(h.unaryInts, selector.UnaryServerInterceptor(auditor.UnaryServerInterceptor(), matcher))
+			h.streamInts = append(h.streamInts, selector.StreamServerInterceptor(auditor.StreamServerInterceptor(), matcher))
+		}
+		return nil
+	}
+}
+
 // WithRegistrations returns a GRPCHandlerOption that registers grpc servers and gateway clients.
 // The given registrations must implement ServerServiceRegister or GatewayClientRegister.
 func WithRegistrations(regs ...any) GRPCHandlerOption {
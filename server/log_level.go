@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+
+	"github.com/choral-io/gommerce-server-core/logging"
+	"github.com/choral-io/gommerce-server-core/secure"
+)
+
+// defaultLogLevelTTL is the ttl of temporary logging levels if it is not specified.
+const defaultLogLevelTTL = 15 * time.Minute
+
+type logLevelState struct {
+	Level   string     `json:"level"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+type logLevelRequest struct {
+	Level string `json:"level"`
+	TTL   string `json:"ttl,omitempty"`
+}
+
+// NewLogLevelRoute returns a ServerMuxRoute to get and temporarily change the logging level at runtime.
+// The route always requires authentication, requests are authorized by the given ServerAuthorizer with
+// secure.AuthFuncAuthenticated and the given auth functions, for example secure.AuthFuncRequireScope("admin").
+//
+//	GET {pattern}                                     -> {"level":"INFO"}
+//	PUT {pattern} {"level":"debug","ttl":"10m"}       -> {"level":"DEBUG","expires":"..."}
+//
+// The level is reverted after ttl, which defaults to 15 minutes.
+func NewLogLevelRoute(pattern string, auth *secure.ServerAuthorizer, funcs ...secure.AuthFunc) ServerMuxRoute {
+	funcs = append([]secure.AuthFunc{secure.AuthFuncAuthenticated}, funcs...)
+	return ServerMuxRoute{
+		Methods: []string{http.MethodGet, http.MethodPut},
+		Pattern: pattern,
+		Handler: func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+			ctx, err := auth.AuthorizeRequest(r, funcs...)
+			if err != nil {
+				http.Error(w, err.Error(), runtime.HTTPStatusFromCode(status.Code(err)))
+				return
+			}
+			if r.Method == http.MethodPut {
+				var req logLevelRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				level, err := logging.ParseLevel(req.Level)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				ttl := defaultLogLevelTTL
+				if req.TTL != "" {
+					if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+						http.Error(w, "invalid ttl: "+req.TTL, http.StatusBadRequest)
+						return
+					}
+				}
+				logging.SetLevelTemporarily(level, ttl)
+				logging.DefaultLogger().Warn(ctx, "logging level changed", "level", level.String(), "ttl", ttl.String())
+			}
+			state := logLevelState{Level: logging.GetLevel().String()}
+			if expiry := logging.GetLevelExpiry(); !expiry.IsZero() {
+				state.Expires = &expiry
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(state)
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/choral-io/gommerce-server-core/errors"
+	"github.com/choral-io/gommerce-server-core/logging"
+)
+
+const (
+	instrumentationName = "github.com/choral-io/gommerce-server-core/server"
+
+	attrErrorReference = attribute.Key("error.reference")
+)
+
+// ErrInternal matches errors returned to clients when a panic is recovered,
+// which carry the error reference id in the message and metadata.
+var ErrInternal = errors.New(codes.Internal, "INTERNAL", "internal error")
+
+// NewRecoveryHandler returns a recovery handler, which logs the panic value and stack with logger,
+// records it on the active span, counts it with the rpc.server.panics metric,
+// and returns ErrInternal with a short reference id, which is logged as well, instead of the panic value.
+func NewRecoveryHandler(logger logging.Logger, mp metric.MeterProvider) (recovery.RecoveryHandlerFuncContext, error) {
+	logger = logging.Named(logger, "grpc.recovery")
+	counter, err := mp.Meter(instrumentationName).Int64Counter("rpc.server.panics",
+		metric.WithUnit("{panic}"),
+		metric.WithDescription("Number of panics recovered from grpc handlers."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, p any) error {
+		stack := string(debug.Stack())
+		ref := newErrorReference()
+		fullMethod, _ := grpc.Method(ctx)
+		// the full method is in the form of "/package.Service/Method", split like otelgrpc
+		service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+		logger.Error(ctx, "recovered from panic", "panic", fmt.Sprint(p), "stack", stack, string(attrErrorReference), ref,
+			string(semconv.RPCServiceKey), service, string(semconv.RPCMethodKey), method)
+		if span := trace.SpanFromContext(ctx); span.IsRecording() {
+			span.RecordError(fmt.Errorf("panic: %v", p), trace.WithAttributes(semconv.ExceptionStacktrace(stack)))
+			span.SetAttributes(semconv.ExceptionEscaped(true), attrErrorReference.String(ref))
+			span.SetStatus(otelcodes.Error, "panic")
+		}
+		counter.Add(ctx, 1, metric.WithAttributes(semconv.RPCService(service), semconv.RPCMethod(method)))
+		return errors.Newf(codes.Internal, ErrInternal.Reason(), "internal error, reference: %s", ref).WithMetadata("reference", ref)
+	}, nil
+}
+
+// WithDefaultRecoveryInterceptor returns a GRPCHandlerOption that adds a recovery interceptor with the handler of NewRecoveryHandler.
+func WithDefaultRecoveryInterceptor(logger logging.Logger, mp metric.MeterProvider) GRPCHandlerOption {
+	return func(h *GRPCHandler) error {
+		f, err := NewRecoveryHandler(logger, mp)
+		if err != nil {
+			return err
+		}
+		return WithRecoveryInterceptor(f)(h)
+	}
+}
+
+// newErrorReference returns a short random id to correlate errors returned to clients with logs.
+func newErrorReference() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}